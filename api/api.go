@@ -3,12 +3,14 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/coroot/coroot/api/views"
 	"github.com/coroot/coroot/auditor"
 	"github.com/coroot/coroot/cache"
 	"github.com/coroot/coroot/constructor"
 	"github.com/coroot/coroot/db"
 	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/notifications"
 	"github.com/coroot/coroot/prom"
 	"github.com/coroot/coroot/timeseries"
 	"github.com/coroot/coroot/utils"
@@ -16,9 +18,14 @@ import (
 	"k8s.io/klog"
 	"net/http"
 	"sort"
+	"strconv"
 	"time"
 )
 
+const (
+	sloComplianceStep = 15 * timeseries.Minute
+)
+
 type Api struct {
 	cache    *cache.Cache
 	db       *db.DB
@@ -338,6 +345,523 @@ func (api *Api) Integration(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (api *Api) NotificationTemplatePreview(w http.ResponseWriter, r *http.Request) {
+	projectId := db.ProjectId(mux.Vars(r)["project"])
+	project, err := api.db.GetProject(projectId)
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	var form NotificationTemplatePreviewForm
+	if err := ReadAndValidate(r, &form); err != nil {
+		klog.Warningln("bad request:", err)
+		http.Error(w, "Invalid template kind", http.StatusBadRequest)
+		return
+	}
+	text, err := notifications.PreviewTemplate(project, form.Kind, form.Template, testNotification(project))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	utils.WriteJson(w, struct {
+		Text string `json:"text"`
+	}{Text: text})
+}
+
+func (api *Api) EscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	projectId := db.ProjectId(mux.Vars(r)["project"])
+
+	if r.Method == http.MethodPost {
+		if api.readOnly {
+			return
+		}
+		var form EscalationPolicyForm
+		if err := ReadAndValidate(r, &form); err != nil {
+			klog.Warningln("bad request:", err)
+			http.Error(w, "Invalid escalation policy", http.StatusBadRequest)
+			return
+		}
+		var policy *db.EscalationPolicy
+		if form.RenotifyInterval > 0 || form.EscalateTo != "" {
+			policy = &form.EscalationPolicy
+		}
+		if err := api.db.SaveEscalationPolicy(projectId, policy); err != nil {
+			klog.Errorln("failed to save:", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		return
+	}
+
+	p, err := api.db.GetProject(projectId)
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	policy := p.Settings.EscalationPolicy
+	if policy == nil {
+		policy = &db.EscalationPolicy{}
+	}
+	utils.WriteJson(w, policy)
+}
+
+func (api *Api) CustomChecks(w http.ResponseWriter, r *http.Request) {
+	projectId := db.ProjectId(mux.Vars(r)["project"])
+
+	if r.Method == http.MethodPost {
+		if api.readOnly {
+			return
+		}
+		var form CustomChecksForm
+		if err := ReadAndValidate(r, &form); err != nil {
+			klog.Warningln("bad request:", err)
+			http.Error(w, "Invalid custom checks", http.StatusBadRequest)
+			return
+		}
+		if err := api.db.SaveCustomChecks(projectId, form.Checks); err != nil {
+			klog.Errorln("failed to save:", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		return
+	}
+
+	p, err := api.db.GetProject(projectId)
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	checks := p.Settings.CustomChecks
+	if checks == nil {
+		checks = []model.CustomCheck{}
+	}
+	utils.WriteJson(w, checks)
+}
+
+func (api *Api) NotificationPolicy(w http.ResponseWriter, r *http.Request) {
+	projectId := db.ProjectId(mux.Vars(r)["project"])
+
+	if r.Method == http.MethodPost {
+		if api.readOnly {
+			return
+		}
+		var form NotificationPolicyForm
+		if err := ReadAndValidate(r, &form); err != nil {
+			klog.Warningln("bad request:", err)
+			http.Error(w, "Invalid notification policy", http.StatusBadRequest)
+			return
+		}
+		var policy *db.NotificationPolicy
+		if form.MinOpenDuration > 0 || form.ResolveCooldown > 0 || form.DigestInterval > 0 {
+			policy = &form.NotificationPolicy
+		}
+		if err := api.db.SaveNotificationPolicy(projectId, policy); err != nil {
+			klog.Errorln("failed to save:", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		return
+	}
+
+	p, err := api.db.GetProject(projectId)
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	policy := p.Settings.NotificationPolicy
+	if policy == nil {
+		policy = &db.NotificationPolicy{}
+	}
+	utils.WriteJson(w, policy)
+}
+
+func (api *Api) Incidents(w http.ResponseWriter, r *http.Request) {
+	projectId := db.ProjectId(mux.Vars(r)["project"])
+	now := timeseries.Now()
+	q := r.URL.Query()
+	filter := db.IncidentFilter{
+		From:  utils.ParseTime(now, q.Get("from"), now.Add(-7*timeseries.Day)),
+		To:    utils.ParseTime(now, q.Get("to"), now),
+		Limit: 50,
+	}
+	if app := q.Get("app"); app != "" {
+		id, err := model.NewApplicationIdFromString(app)
+		if err != nil {
+			klog.Warningln(err)
+			http.Error(w, "Invalid application id", http.StatusBadRequest)
+			return
+		}
+		filter.ApplicationId = id
+	}
+	switch q.Get("severity") {
+	case "":
+	case "warning":
+		filter.Severity = model.WARNING
+	case "critical":
+		filter.Severity = model.CRITICAL
+	default:
+		http.Error(w, "Invalid severity", http.StatusBadRequest)
+		return
+	}
+	switch q.Get("status") {
+	case "":
+	case "open":
+		resolved := false
+		filter.Resolved = &resolved
+	case "resolved":
+		resolved := true
+		filter.Resolved = &resolved
+	default:
+		http.Error(w, "Invalid status", http.StatusBadRequest)
+		return
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > 1000 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = limit
+	}
+	if v := q.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			http.Error(w, "Invalid offset", http.StatusBadRequest)
+			return
+		}
+		filter.Offset = offset
+	}
+
+	incidents, total, err := api.db.GetIncidents(projectId, filter)
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	keys := make([]string, 0, len(incidents))
+	for _, i := range incidents {
+		keys = append(keys, i.Key)
+	}
+	notifications, err := api.db.GetIncidentsNotifications(projectId, keys)
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	utils.WriteJson(w, views.Incidents(incidents, total, notifications, now))
+}
+
+func (api *Api) DeadLetters(w http.ResponseWriter, r *http.Request) {
+	projectId := db.ProjectId(mux.Vars(r)["project"])
+	now := timeseries.Now()
+
+	if r.Method == http.MethodPost {
+		if api.readOnly {
+			return
+		}
+		var form DeadLetterForm
+		if err := ReadAndValidate(r, &form); err != nil {
+			klog.Warningln("bad request:", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+		for _, n := range form.Notifications {
+			var err error
+			switch form.Action {
+			case "retry":
+				err = api.db.RetryIncidentNotification(projectId, n.IncidentKey, n.Destination, n.Timestamp, now)
+			case "discard":
+				err = api.db.DiscardIncidentNotification(projectId, n.IncidentKey, n.Destination, n.Timestamp, now)
+			}
+			if err != nil {
+				if errors.Is(err, db.ErrNotFound) {
+					http.Error(w, "Notification not found", http.StatusNotFound)
+					return
+				}
+				klog.Errorln(err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+		}
+		return
+	}
+
+	deadLetters, err := api.db.GetDeadLetterIncidentNotifications(projectId, notifications.DeadLetterBefore(now))
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	utils.WriteJson(w, views.DeadLetters(deadLetters))
+}
+
+func (api *Api) ConfigHistory(w http.ResponseWriter, r *http.Request) {
+	projectId := db.ProjectId(mux.Vars(r)["project"])
+
+	if r.Method == http.MethodPost {
+		if api.readOnly {
+			return
+		}
+		var form ConfigRollbackForm
+		if err := ReadAndValidate(r, &form); err != nil {
+			klog.Warningln("bad request:", err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+		if err := api.db.RollbackConfigChange(projectId, form.Version); err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				http.Error(w, "Version not found", http.StatusNotFound)
+				return
+			}
+			klog.Errorln("failed to rollback config change:", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		return
+	}
+
+	q := r.URL.Query()
+	var appId model.ApplicationId
+	if v := q.Get("app"); v != "" {
+		var err error
+		if appId, err = model.NewApplicationIdFromString(v); err != nil {
+			klog.Warningln(err)
+			http.Error(w, "invalid application id: "+v, http.StatusBadRequest)
+			return
+		}
+	}
+	changes, err := api.db.GetConfigHistory(projectId, db.ConfigScope(q.Get("scope")), appId, model.CheckId(q.Get("check")))
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	utils.WriteJson(w, views.ConfigHistory(changes))
+}
+
+func (api *Api) SLOCompliance(w http.ResponseWriter, r *http.Request) {
+	projectId := db.ProjectId(mux.Vars(r)["project"])
+	q := r.URL.Query()
+	project, err := api.db.GetProject(projectId)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			http.Error(w, "Project not found", http.StatusNotFound)
+			return
+		}
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	offset := 0
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			http.Error(w, "Invalid offset", http.StatusBadRequest)
+			return
+		}
+	}
+	period := q.Get("period")
+	if period == "" {
+		period = "month"
+	}
+	now := time.Now().UTC()
+	var start time.Time
+	var months int
+	switch period {
+	case "month":
+		months = 1
+		start = time.Date(now.Year(), now.Month()-time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+	case "quarter":
+		months = 3
+		start = time.Date(now.Year(), (now.Month()-1)/3*3+1-time.Month(3*offset), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		http.Error(w, "Invalid period", http.StatusBadRequest)
+		return
+	}
+	end := start.AddDate(0, months, 0)
+	if end.After(now) {
+		end = now
+	}
+	from, to := timeseries.Time(start.Unix()), timeseries.Time(end.Unix())
+
+	filter := db.IncidentFilter{From: from, To: to, Limit: 10000}
+	if app := q.Get("app"); app != "" {
+		id, err := model.NewApplicationIdFromString(app)
+		if err != nil {
+			klog.Warningln(err)
+			http.Error(w, "Invalid application id", http.StatusBadRequest)
+			return
+		}
+		filter.ApplicationId = id
+	}
+
+	cc := api.cache.GetCacheClient(project)
+	cacheTo, err := cc.GetTo()
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	if cacheTo.IsZero() || cacheTo.Before(from) {
+		http.Error(w, "No data for the requested period", http.StatusNotFound)
+		return
+	}
+	if cacheTo.Before(to) { // the period is not shifted, so it stays aligned to the calendar
+		to = cacheTo
+	}
+	step := maxDuration(project.Prometheus.RefreshInterval, sloComplianceStep)
+	to = to.Truncate(step)
+	world, err := constructor.New(api.db, project, cc, constructor.OptionDoNotLoadRawSLIs).LoadSLIs(r.Context(), from, to, step)
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	if !filter.ApplicationId.IsZero() {
+		app := world.GetApplication(filter.ApplicationId)
+		if app == nil {
+			http.Error(w, "Application not found", http.StatusNotFound)
+			return
+		}
+		world.Applications = []*model.Application{app}
+	}
+	incidents, _, err := api.db.GetIncidents(projectId, filter)
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	keys := make([]string, 0, len(incidents))
+	for _, i := range incidents {
+		keys = append(keys, i.Key)
+	}
+	checkTransitions, err := api.db.GetIncidentsCheckTransitions(projectId, keys)
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	view := views.SLOCompliance(world, period, from, to, incidents, checkTransitions)
+	if q.Get("format") == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="slo-%s-%s.csv"`, period, start.Format("2006-01")))
+		if err := view.CSV(w); err != nil {
+			klog.Errorln(err)
+		}
+		return
+	}
+	utils.WriteJson(w, view)
+}
+
+func (api *Api) Incident(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	projectId := db.ProjectId(vars["project"])
+	incidentKey := vars["incident"]
+
+	if api.readOnly {
+		return
+	}
+	var form IncidentForm
+	if err := ReadAndValidate(r, &form); err != nil {
+		klog.Warningln("bad request:", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	var acknowledgedAt timeseries.Time
+	if form.Ack {
+		acknowledgedAt = timeseries.Now()
+	}
+	if err := api.db.AcknowledgeIncident(projectId, incidentKey, acknowledgedAt); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			http.Error(w, "Incident not found", http.StatusNotFound)
+			return
+		}
+		klog.Errorln("failed to acknowledge incident:", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (api *Api) IncidentTimeline(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	projectId := db.ProjectId(vars["project"])
+	incident, err := api.db.GetIncidentByKey(projectId, vars["incident"])
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			http.Error(w, "Incident not found", http.StatusNotFound)
+			return
+		}
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	world, project, err := api.loadWorldByRequest(r)
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	if world == nil {
+		return
+	}
+	app := world.GetApplication(incident.ApplicationId)
+	if app == nil {
+		klog.Warningln("application not found:", incident.ApplicationId)
+		http.Error(w, "Application not found", http.StatusNotFound)
+		return
+	}
+	notifications, err := api.db.GetIncidentNotifications(projectId, incident.Key)
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	checkTransitions, err := api.db.GetIncidentCheckTransitions(projectId, incident.Key)
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+	upstreamIncidents := map[model.ApplicationId][]db.Incident{}
+	for _, i := range app.Instances {
+		for _, u := range i.Upstreams {
+			if u.RemoteInstance == nil || u.RemoteInstance.OwnerId == app.Id {
+				continue
+			}
+			upstreamId := u.RemoteInstance.OwnerId
+			if _, ok := upstreamIncidents[upstreamId]; ok {
+				continue
+			}
+			incidents, err := api.db.GetIncidentsByApp(projectId, upstreamId, world.Ctx.From, world.Ctx.To)
+			if err != nil {
+				klog.Errorln(err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+			upstreamIncidents[upstreamId] = incidents
+		}
+	}
+	auditor.Audit(world, project)
+	timeline := views.IncidentTimeline(world, app, *incident, notifications, checkTransitions, upstreamIncidents)
+
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+		utils.WriteJson(w, timeline)
+	case "md", "markdown":
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.Header().Set("Content-Disposition", "attachment; filename=incident-"+incident.Key+".md")
+		_, _ = w.Write([]byte(timeline.Markdown()))
+	default:
+		http.Error(w, "Invalid format", http.StatusBadRequest)
+	}
+}
+
 func (api *Api) Prom(w http.ResponseWriter, r *http.Request) {
 	projectId := db.ProjectId(mux.Vars(r)["project"])
 	project, err := api.db.GetProject(projectId)
@@ -431,19 +955,33 @@ func (api *Api) Check(w http.ResponseWriter, r *http.Request) {
 		}
 		switch checkId {
 		case model.Checks.SLOAvailability.Id:
-			cfg, def := checkConfigs.GetAvailability(appId)
-			res.Form = CheckConfigSLOAvailabilityForm{Configs: []model.CheckConfigSLOAvailability{cfg}, Default: def}
+			cfgs, def := checkConfigs.GetAvailabilityAll(appId)
+			res.Form = CheckConfigSLOAvailabilityForm{Configs: cfgs, Default: def}
 		case model.Checks.SLOLatency.Id:
-			cfg, def := checkConfigs.GetLatency(appId, model.CalcApplicationCategory(appId, project.Settings.ApplicationCategories))
-			res.Form = CheckConfigSLOLatencyForm{Configs: []model.CheckConfigSLOLatency{cfg}, Default: def}
+			cfgs, def := checkConfigs.GetLatencyAll(appId, model.CalcApplicationCategory(appId, project.Settings.ApplicationCategories))
+			res.Form = CheckConfigSLOLatencyForm{Configs: cfgs, Default: def}
+		case model.Checks.SLODependency.Id:
+			res.Form = CheckConfigSLODependencyForm{Configs: checkConfigs.GetDependencies(appId)}
+		case model.AlertRulesId:
+			res.Form = CheckConfigAlertRulesForm{Configs: checkConfigs.GetAlertRulesAll(appId)}
 		default:
+			category := model.CalcApplicationCategory(appId, project.Settings.ApplicationCategories)
 			form := CheckConfigForm{
-				Configs: checkConfigs.GetSimpleAll(checkId, appId),
+				Configs:   checkConfigs.GetSimpleAll(checkId, appId),
+				Category:  category,
+				Namespace: appId.Namespace,
 			}
 			if len(form.Configs) == 0 {
 				http.Error(w, "", http.StatusNotFound)
 				return
 			}
+			_, form.Level = checkConfigs.GetSimpleWithLevel(checkId, appId, category)
+			form.Overrides = map[model.CheckConfigLevel]*model.CheckConfigSimple{
+				model.CheckConfigLevelCategory: checkConfigs.GetSimpleOverride(checkId, model.CategoryConfigId(category)),
+			}
+			if appId.HasNamespace() {
+				form.Overrides[model.CheckConfigLevelNamespace] = checkConfigs.GetSimpleOverride(checkId, model.NamespaceConfigId(appId.Namespace))
+			}
 			res.Form = form
 		}
 		utils.WriteJson(w, res)
@@ -478,6 +1016,41 @@ func (api *Api) Check(w http.ResponseWriter, r *http.Request) {
 				http.Error(w, "", http.StatusInternalServerError)
 				return
 			}
+		case model.Checks.SLODependency.Id:
+			var form CheckConfigSLODependencyForm
+			if err := ReadAndValidate(r, &form); err != nil {
+				klog.Warningln("bad request:", err)
+				http.Error(w, "", http.StatusBadRequest)
+				return
+			}
+			if err := api.db.SaveCheckConfig(projectId, appId, checkId, form.Configs); err != nil {
+				klog.Errorln("failed to save check config:", err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+		case model.AlertRulesId:
+			var form CheckConfigAlertRulesForm
+			if err := ReadAndValidate(r, &form); err != nil {
+				klog.Warningln("bad request:", err)
+				http.Error(w, "", http.StatusBadRequest)
+				return
+			}
+			for level, rules := range form.Configs {
+				var id model.ApplicationId
+				switch level {
+				case 0:
+					continue
+				case 1:
+					id = model.ApplicationIdZero
+				case 2:
+					id = appId
+				}
+				if err := api.db.SaveCheckConfig(projectId, id, checkId, rules); err != nil {
+					klog.Errorln("failed to save check config:", err)
+					http.Error(w, "", http.StatusInternalServerError)
+					return
+				}
+			}
 		default:
 			var form CheckConfigForm
 			if err := ReadAndValidate(r, &form); err != nil {
@@ -485,6 +1058,11 @@ func (api *Api) Check(w http.ResponseWriter, r *http.Request) {
 				http.Error(w, "", http.StatusBadRequest)
 				return
 			}
+			if !form.ValidFor(checkId) {
+				klog.Warningln("bad request: the check does not support the for duration:", checkId)
+				http.Error(w, "", http.StatusBadRequest)
+				return
+			}
 			for level, cfg := range form.Configs {
 				var id model.ApplicationId
 				switch level {
@@ -501,9 +1079,76 @@ func (api *Api) Check(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 			}
+			if len(form.Overrides) == 0 {
+				return
+			}
+			project, err := api.db.GetProject(projectId)
+			if err != nil {
+				klog.Errorln("failed to get project:", err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+			for level, cfg := range form.Overrides {
+				var id model.ApplicationId
+				switch level {
+				case model.CheckConfigLevelCategory:
+					id = model.CategoryConfigId(model.CalcApplicationCategory(appId, project.Settings.ApplicationCategories))
+				case model.CheckConfigLevelNamespace:
+					if !appId.HasNamespace() {
+						continue
+					}
+					id = model.NamespaceConfigId(appId.Namespace)
+				}
+				if err := api.db.SaveCheckConfig(projectId, id, checkId, cfg); err != nil {
+					klog.Errorln("failed to save check config:", err)
+					http.Error(w, "", http.StatusInternalServerError)
+					return
+				}
+			}
+			return
+		}
+	}
+}
+
+func (api *Api) KafkaConsumerGroups(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	projectId := db.ProjectId(vars["project"])
+	appId, err := model.NewApplicationIdFromString(vars["app"])
+	if err != nil {
+		klog.Warningln(err)
+		http.Error(w, "invalid application id: "+vars["app"], http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		if api.readOnly {
 			return
 		}
+		var form ApplicationSettingsKafkaForm
+		if err := ReadAndValidate(r, &form); err != nil {
+			klog.Warningln("bad request:", err)
+			http.Error(w, "invalid data", http.StatusBadRequest)
+			return
+		}
+		if err := api.db.SaveApplicationSetting(projectId, appId, &form.ApplicationSettingsKafka); err != nil {
+			klog.Errorln(err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		return
+	}
+
+	settings, err := api.db.GetApplicationSettings(projectId, appId)
+	if err != nil {
+		klog.Errorln(err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return
 	}
+	res := db.ApplicationSettingsKafka{}
+	if settings != nil && settings.Kafka != nil {
+		res = *settings.Kafka
+	}
+	utils.WriteJson(w, res)
 }
 
 func (api *Api) Profile(w http.ResponseWriter, r *http.Request) {
@@ -625,6 +1270,9 @@ func (api *Api) loadWorldByRequest(r *http.Request) (*model.World, *db.Project,
 	to := utils.ParseTime(now, q.Get("to"), now)
 
 	incidentKey := q.Get("incident")
+	if incidentKey == "" {
+		incidentKey = mux.Vars(r)["incident"]
+	}
 	if incidentKey != "" {
 		if incident, err := api.db.GetIncidentByKey(projectId, incidentKey); err != nil {
 			klog.Warningln("failed to get incident:", err)
@@ -8,6 +8,7 @@ import (
 	"github.com/coroot/coroot/notifications"
 	"github.com/coroot/coroot/profiling"
 	"github.com/coroot/coroot/prom"
+	"github.com/coroot/coroot/timeseries"
 	"github.com/coroot/coroot/utils"
 	"net/http"
 	"net/url"
@@ -56,10 +57,108 @@ func (f *ProjectStatusForm) Valid() bool {
 }
 
 type CheckConfigForm struct {
-	Configs []*model.CheckConfigSimple `json:"configs"`
+	Configs   []*model.CheckConfigSimple                          `json:"configs"`
+	Overrides map[model.CheckConfigLevel]*model.CheckConfigSimple `json:"overrides"`
+	Category  model.ApplicationCategory                           `json:"category"`
+	Namespace string                                              `json:"namespace"`
+	Level     model.CheckConfigLevel                              `json:"level"`
 }
 
 func (f *CheckConfigForm) Valid() bool {
+	for _, c := range f.Configs {
+		if c != nil && !validSimpleCheckConfig(c) {
+			return false
+		}
+	}
+	for level, c := range f.Overrides {
+		switch level {
+		case model.CheckConfigLevelCategory, model.CheckConfigLevelNamespace:
+		default:
+			return false
+		}
+		if c != nil && !validSimpleCheckConfig(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *CheckConfigForm) ValidFor(checkId model.CheckId) bool {
+	if model.CheckSupportsFor(checkId) {
+		return true
+	}
+	for _, c := range f.Configs {
+		if c != nil && c.For > 0 {
+			return false
+		}
+	}
+	for _, c := range f.Overrides {
+		if c != nil && c.For > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func validSimpleCheckConfig(c *model.CheckConfigSimple) bool {
+	if c.CriticalThreshold != nil && *c.CriticalThreshold < c.Threshold {
+		return false
+	}
+	if c.For < 0 || c.For > model.CheckEvaluationWindow || c.Horizon < 0 {
+		return false
+	}
+	return true
+}
+
+type CheckConfigAlertRulesForm struct {
+	Configs [][]model.AlertRule `json:"configs"`
+}
+
+func (f *CheckConfigAlertRulesForm) Valid() bool {
+	if len(f.Configs) > 3 {
+		return false
+	}
+	for _, rules := range f.Configs {
+		for _, r := range rules {
+			if !r.Valid() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type CustomChecksForm struct {
+	Checks []model.CustomCheck `json:"checks"`
+}
+
+func (f *CustomChecksForm) Valid() bool {
+	ids := map[string]bool{}
+	for i := range f.Checks {
+		if f.Checks[i].Validate() != nil {
+			return false
+		}
+		if ids[f.Checks[i].Id] {
+			return false
+		}
+		ids[f.Checks[i].Id] = true
+	}
+	return true
+}
+
+type CheckConfigSLODependencyForm struct {
+	Configs []model.CheckConfigSLODependency `json:"configs"`
+}
+
+func (f *CheckConfigSLODependencyForm) Valid() bool {
+	for _, c := range f.Configs {
+		if c.Upstream.IsZero() || c.AvailabilityObjectivePercentage <= 0 || c.AvailabilityObjectivePercentage >= 100 {
+			return false
+		}
+		if c.LatencyObjectiveBucket > 0 && (c.LatencyObjectivePercentage <= 0 || c.LatencyObjectivePercentage >= 100) {
+			return false
+		}
+	}
 	return true
 }
 
@@ -69,12 +168,14 @@ type CheckConfigSLOAvailabilityForm struct {
 }
 
 func (f *CheckConfigSLOAvailabilityForm) Valid() bool {
+	names := make([]string, 0, len(f.Configs))
 	for _, c := range f.Configs {
 		if c.Custom && (c.TotalRequestsQuery == "" || c.FailedRequestsQuery == "") {
 			return false
 		}
+		names = append(names, c.Name)
 	}
-	return true
+	return uniqueSLONames(names)
 }
 
 type CheckConfigSLOLatencyForm struct {
@@ -83,10 +184,28 @@ type CheckConfigSLOLatencyForm struct {
 }
 
 func (f *CheckConfigSLOLatencyForm) Valid() bool {
+	names := make([]string, 0, len(f.Configs))
 	for _, c := range f.Configs {
 		if c.Custom && (c.HistogramQuery == "" || c.ObjectiveBucket <= 0) {
 			return false
 		}
+		names = append(names, c.Name)
+	}
+	return uniqueSLONames(names)
+}
+
+// uniqueSLONames checks that the SLOs of the same kind can be told apart: they share a check id, so the name is the only key.
+func uniqueSLONames(names []string) bool {
+	if len(names) < 2 {
+		return true
+	}
+	seen := map[string]bool{}
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if n == "" || seen[n] {
+			return false
+		}
+		seen[n] = true
 	}
 	return true
 }
@@ -124,6 +243,94 @@ func (f *ApplicationSettingsPyroscopeForm) Valid() bool {
 	return true
 }
 
+type ApplicationSettingsKafkaForm struct {
+	db.ApplicationSettingsKafka
+}
+
+func (f *ApplicationSettingsKafkaForm) Valid() bool {
+	for _, g := range f.ConsumerGroups {
+		if strings.TrimSpace(g) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+type EscalationPolicyForm struct {
+	db.EscalationPolicy
+}
+
+func (f *EscalationPolicyForm) Valid() bool {
+	if f.RenotifyInterval < 0 || f.EscalateAfter < 0 {
+		return false
+	}
+	switch f.EscalateTo {
+	case "":
+		return true
+	case db.IntegrationTypeSlack, db.IntegrationTypeTeams, db.IntegrationTypePagerduty, db.IntegrationTypeOpsgenie, db.IntegrationTypeMattermost, db.IntegrationTypeTelegram:
+		return f.EscalateAfter > 0
+	}
+	return false
+}
+
+type NotificationPolicyForm struct {
+	db.NotificationPolicy
+}
+
+func (f *NotificationPolicyForm) Valid() bool {
+	if f.MinOpenDuration < 0 || f.ResolveCooldown < 0 || f.DigestInterval < 0 {
+		return false
+	}
+	if f.DigestInterval > db.MaxDigestInterval {
+		return false
+	}
+	return true
+}
+
+type DeadLetterForm struct {
+	Action        string                   `json:"action"`
+	Notifications []DeadLetterNotification `json:"notifications"`
+}
+
+type DeadLetterNotification struct {
+	IncidentKey string             `json:"incident_key"`
+	Destination db.IntegrationType `json:"destination"`
+	Timestamp   timeseries.Time    `json:"timestamp"`
+}
+
+func (f *DeadLetterForm) Valid() bool {
+	switch f.Action {
+	case "retry", "discard":
+	default:
+		return false
+	}
+	if len(f.Notifications) == 0 {
+		return false
+	}
+	for _, n := range f.Notifications {
+		if n.IncidentKey == "" || n.Destination == "" || n.Timestamp.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+type ConfigRollbackForm struct {
+	Version int `json:"version"`
+}
+
+func (f *ConfigRollbackForm) Valid() bool {
+	return f.Version > 0
+}
+
+type IncidentForm struct {
+	Ack bool `json:"ack"`
+}
+
+func (f *IncidentForm) Valid() bool {
+	return true
+}
+
 type IntegrationsForm struct {
 	BaseUrl string `json:"base_url"`
 }
@@ -157,6 +364,10 @@ func NewIntegrationForm(t db.IntegrationType) IntegrationForm {
 		return &IntegrationFormPagerduty{}
 	case db.IntegrationTypeOpsgenie:
 		return &IntegrationFormOpsgenie{}
+	case db.IntegrationTypeTelegram:
+		return &IntegrationFormTelegram{}
+	case db.IntegrationTypeMattermost:
+		return &IntegrationFormMattermost{}
 	}
 	return nil
 }
@@ -262,7 +473,7 @@ func (f *IntegrationFormSlack) Valid() bool {
 	if f.Token == "" || f.DefaultChannel == "" {
 		return false
 	}
-	return true
+	return validTemplates(f.Templates)
 }
 
 func (f *IntegrationFormSlack) Get(project *db.Project, masked bool) {
@@ -288,7 +499,9 @@ func (f *IntegrationFormSlack) Update(ctx context.Context, project *db.Project,
 }
 
 func (f *IntegrationFormSlack) Test(ctx context.Context, project *db.Project) error {
-	return notifications.NewSlack(f.Token, f.DefaultChannel).SendIncident(ctx, project.Settings.Integrations.BaseUrl, testNotification(project))
+	client := notifications.NewSlack(f.Token, f.DefaultChannel)
+	client.SetTemplates(project.Name, f.Templates)
+	return client.SendIncident(ctx, project.Settings.Integrations.BaseUrl, testNotification(project))
 }
 
 type IntegrationFormTeams struct {
@@ -299,7 +512,7 @@ func (f *IntegrationFormTeams) Valid() bool {
 	if f.WebhookUrl == "" {
 		return false
 	}
-	return true
+	return validTemplates(f.Templates)
 }
 
 func (f *IntegrationFormTeams) Get(project *db.Project, masked bool) {
@@ -325,7 +538,9 @@ func (f *IntegrationFormTeams) Update(ctx context.Context, project *db.Project,
 }
 
 func (f *IntegrationFormTeams) Test(ctx context.Context, project *db.Project) error {
-	return notifications.NewTeams(f.WebhookUrl).SendIncident(ctx, project.Settings.Integrations.BaseUrl, testNotification(project))
+	client := notifications.NewTeams(f.WebhookUrl)
+	client.SetTemplates(project.Name, f.Templates)
+	return client.SendIncident(ctx, project.Settings.Integrations.BaseUrl, testNotification(project))
 }
 
 type IntegrationFormPagerduty struct {
@@ -336,7 +551,7 @@ func (f *IntegrationFormPagerduty) Valid() bool {
 	if f.IntegrationKey == "" {
 		return false
 	}
-	return true
+	return validTemplates(f.Templates)
 }
 
 func (f *IntegrationFormPagerduty) Get(project *db.Project, masked bool) {
@@ -361,7 +576,9 @@ func (f *IntegrationFormPagerduty) Update(ctx context.Context, project *db.Proje
 }
 
 func (f *IntegrationFormPagerduty) Test(ctx context.Context, project *db.Project) error {
-	return notifications.NewPagerduty(f.IntegrationKey).SendIncident(ctx, project.Settings.Integrations.BaseUrl, testNotification(project))
+	client := notifications.NewPagerduty(f.IntegrationKey)
+	client.SetTemplates(project.Name, f.Templates)
+	return client.SendIncident(ctx, project.Settings.Integrations.BaseUrl, testNotification(project))
 }
 
 type IntegrationFormOpsgenie struct {
@@ -372,7 +589,7 @@ func (f *IntegrationFormOpsgenie) Valid() bool {
 	if f.ApiKey == "" {
 		return false
 	}
-	return true
+	return validTemplates(f.Templates)
 }
 
 func (f *IntegrationFormOpsgenie) Get(project *db.Project, masked bool) {
@@ -397,7 +614,117 @@ func (f *IntegrationFormOpsgenie) Update(ctx context.Context, project *db.Projec
 }
 
 func (f *IntegrationFormOpsgenie) Test(ctx context.Context, project *db.Project) error {
-	return notifications.NewOpsgenie(f.ApiKey, f.EUInstance).SendIncident(ctx, project.Settings.Integrations.BaseUrl, testNotification(project))
+	client := notifications.NewOpsgenie(f.ApiKey, f.EUInstance)
+	client.SetTemplates(project.Name, f.Templates)
+	return client.SendIncident(ctx, project.Settings.Integrations.BaseUrl, testNotification(project))
+}
+
+type NotificationTemplatePreviewForm struct {
+	Kind     notifications.TemplateKind `json:"kind"`
+	Template string                     `json:"template"`
+}
+
+func (f *NotificationTemplatePreviewForm) Valid() bool {
+	switch f.Kind {
+	case notifications.TemplateKindIncidentOpen, notifications.TemplateKindIncidentResolve, notifications.TemplateKindDeploymentSummary:
+		return true
+	}
+	return false
+}
+
+func validTemplates(t db.NotificationTemplates) bool {
+	templates := map[notifications.TemplateKind]string{
+		notifications.TemplateKindIncidentOpen:      t.IncidentOpen,
+		notifications.TemplateKindIncidentResolve:   t.IncidentResolve,
+		notifications.TemplateKindDeploymentSummary: t.DeploymentSummary,
+	}
+	for kind, text := range templates {
+		if err := notifications.ValidateTemplate(kind, text); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+type IntegrationFormTelegram struct {
+	db.IntegrationTelegram
+}
+
+func (f *IntegrationFormTelegram) Valid() bool {
+	if f.BotToken == "" || f.ChatId == "" {
+		return false
+	}
+	return validTemplates(f.Templates)
+}
+
+func (f *IntegrationFormTelegram) Get(project *db.Project, masked bool) {
+	cfg := project.Settings.Integrations.Telegram
+	if cfg == nil {
+		f.Incidents = true
+		f.Deployments = true
+		return
+	}
+	f.IntegrationTelegram = *cfg
+	if masked {
+		f.BotToken = "<bot_token>"
+	}
+}
+
+func (f *IntegrationFormTelegram) Update(ctx context.Context, project *db.Project, clear bool) error {
+	cfg := &f.IntegrationTelegram
+	if clear {
+		cfg = nil
+	}
+	project.Settings.Integrations.Telegram = cfg
+	return nil
+}
+
+func (f *IntegrationFormTelegram) Test(ctx context.Context, project *db.Project) error {
+	client := notifications.NewTelegram(f.BotToken, f.ChatId)
+	client.SetTemplates(project.Name, f.Templates)
+	return client.SendIncident(ctx, project.Settings.Integrations.BaseUrl, testNotification(project))
+}
+
+type IntegrationFormMattermost struct {
+	db.IntegrationMattermost
+}
+
+func (f *IntegrationFormMattermost) Valid() bool {
+	if _, err := url.Parse(f.Url); err != nil || f.Url == "" {
+		return false
+	}
+	if f.Token == "" || f.ChannelId == "" {
+		return false
+	}
+	return validTemplates(f.Templates)
+}
+
+func (f *IntegrationFormMattermost) Get(project *db.Project, masked bool) {
+	cfg := project.Settings.Integrations.Mattermost
+	if cfg == nil {
+		f.Incidents = true
+		f.Deployments = true
+		return
+	}
+	f.IntegrationMattermost = *cfg
+	if masked {
+		f.Token = "<token>"
+	}
+}
+
+func (f *IntegrationFormMattermost) Update(ctx context.Context, project *db.Project, clear bool) error {
+	cfg := &f.IntegrationMattermost
+	if clear {
+		cfg = nil
+	}
+	project.Settings.Integrations.Mattermost = cfg
+	return nil
+}
+
+func (f *IntegrationFormMattermost) Test(ctx context.Context, project *db.Project) error {
+	client := notifications.NewMattermost(f.Url, f.Token, f.ChannelId)
+	client.SetTemplates(project.Name, f.Templates)
+	return client.SendIncident(ctx, project.Settings.Integrations.BaseUrl, testNotification(project))
 }
 
 func testNotification(project *db.Project) *db.IncidentNotification {
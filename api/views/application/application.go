@@ -9,8 +9,9 @@ import (
 )
 
 type View struct {
-	AppMap  *AppMap              `json:"app_map"`
-	Reports []*model.AuditReport `json:"reports"`
+	AppMap         *AppMap                `json:"app_map"`
+	Reports        []*model.AuditReport   `json:"reports"`
+	RootCauseHints []*model.RootCauseHint `json:"root_cause_hints"`
 }
 
 type AppMap struct {
@@ -137,6 +138,10 @@ func Render(world *model.World, app *model.Application, incidents []db.Incident)
 			}
 		}
 		for _, r := range app.Reports {
+			checks := map[model.CheckId]bool{}
+			for _, ch := range r.Checks {
+				checks[ch.Id] = true
+			}
 			for _, w := range r.Widgets {
 				var charts []*model.Chart
 				switch {
@@ -149,6 +154,9 @@ func Render(world *model.World, app *model.Application, incidents []db.Incident)
 				}
 				for _, ch := range charts {
 					for _, i := range incidents {
+						if i.CheckId != "" && !checks[i.CheckId] {
+							continue
+						}
 						ch.AddAnnotation("incident", i.OpenedAt, i.ResolvedAt, "")
 					}
 				}
@@ -157,8 +165,9 @@ func Render(world *model.World, app *model.Application, incidents []db.Incident)
 	}
 
 	v := &View{
-		AppMap:  appMap,
-		Reports: app.Reports,
+		AppMap:         appMap,
+		Reports:        app.Reports,
+		RootCauseHints: app.RootCauseHints,
 	}
 	return v
 }
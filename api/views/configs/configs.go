@@ -14,22 +14,32 @@ type View struct {
 
 type Check struct {
 	model.Check
-	GlobalThreshold      float32       `json:"global_threshold"`
-	ProjectThreshold     *float32      `json:"project_threshold"`
-	ApplicationOverrides []Application `json:"application_overrides"`
+	GlobalThreshold          float32       `json:"global_threshold"`
+	ProjectThreshold         *float32      `json:"project_threshold"`
+	ProjectCriticalThreshold *float32      `json:"project_critical_threshold"`
+	CategoryOverrides        []Override    `json:"category_overrides"`
+	NamespaceOverrides       []Override    `json:"namespace_overrides"`
+	ApplicationOverrides     []Application `json:"application_overrides"`
+}
+
+type Override struct {
+	Name              string   `json:"name"`
+	Threshold         float32  `json:"threshold"`
+	CriticalThreshold *float32 `json:"critical_threshold"`
 }
 
 type Application struct {
-	Id        model.ApplicationId `json:"id"`
-	Threshold float32             `json:"threshold"`
-	Details   string              `json:"details"`
+	Id                model.ApplicationId `json:"id"`
+	Threshold         float32             `json:"threshold"`
+	CriticalThreshold *float32            `json:"critical_threshold"`
+	Details           string              `json:"details"`
 }
 
 func Render(configs model.CheckConfigs) *View {
 	v := &View{configs: configs}
 	cs := model.Checks
 
-	v.addReport(model.AuditReportSLO, cs.SLOAvailability, cs.SLOLatency)
+	v.addReport(model.AuditReportSLO, cs.SLOAvailability, cs.SLOLatency, cs.SLODependency)
 	v.addReport(model.AuditReportInstances, cs.InstanceAvailability, cs.InstanceRestarts)
 	v.addReport(model.AuditReportCPU, cs.CPUNode, cs.CPUContainer)
 	v.addReport(model.AuditReportMemory, cs.MemoryOOM)
@@ -38,6 +48,11 @@ func Render(configs model.CheckConfigs) *View {
 	v.addReport(model.AuditReportLogs, cs.LogErrors)
 	v.addReport(model.AuditReportPostgres, cs.PostgresAvailability, cs.PostgresLatency, cs.PostgresErrors)
 	v.addReport(model.AuditReportRedis, cs.RedisAvailability, cs.RedisLatency)
+	v.addReport(model.AuditReportMysql, cs.MysqlAvailability, cs.MysqlLatency, cs.MysqlReplicationLag, cs.MysqlConnections)
+	v.addReport(model.AuditReportMongodb, cs.MongodbAvailability, cs.MongodbReplicationLag, cs.MongodbConnections, cs.MongodbSlowOperations)
+	v.addReport(model.AuditReportKafka, cs.KafkaAvailability, cs.KafkaUnderReplicatedPartitions, cs.KafkaOfflinePartitions, cs.KafkaConsumerLag)
+	v.addReport(model.AuditReportElasticsearch, cs.ElasticsearchHealth, cs.ElasticsearchLatency, cs.ElasticsearchRejections)
+	v.addReport(model.AuditReportRabbitmq, cs.RabbitmqQueueBacklog)
 
 	return v
 }
@@ -57,13 +72,28 @@ func (v *View) addReport(name model.AuditReportName, checks ...model.CheckConfig
 			for _, unk := range configs {
 				switch cfg := unk.(type) {
 				case model.CheckConfigSimple:
-					if appId.IsZero() {
+					switch appId.ConfigLevel() {
+					case model.CheckConfigLevelProject:
 						t := cfg.Threshold
 						ch.ProjectThreshold = &t
-					} else {
+						ch.ProjectCriticalThreshold = cfg.CriticalThreshold
+					case model.CheckConfigLevelCategory:
+						ch.CategoryOverrides = append(ch.CategoryOverrides, Override{
+							Name:              appId.Name,
+							Threshold:         cfg.Threshold,
+							CriticalThreshold: cfg.CriticalThreshold,
+						})
+					case model.CheckConfigLevelNamespace:
+						ch.NamespaceOverrides = append(ch.NamespaceOverrides, Override{
+							Name:              appId.Namespace,
+							Threshold:         cfg.Threshold,
+							CriticalThreshold: cfg.CriticalThreshold,
+						})
+					default:
 						ch.ApplicationOverrides = append(ch.ApplicationOverrides, Application{
-							Id:        appId,
-							Threshold: cfg.Threshold,
+							Id:                appId,
+							Threshold:         cfg.Threshold,
+							CriticalThreshold: cfg.CriticalThreshold,
 						})
 					}
 				case []model.CheckConfigSLOAvailability:
@@ -81,6 +111,14 @@ func (v *View) addReport(name model.AuditReportName, checks ...model.CheckConfig
 							Details:   "< " + utils.FormatLatency(c.ObjectiveBucket),
 						})
 					}
+				case []model.CheckConfigSLODependency:
+					for _, c := range cfg {
+						ch.ApplicationOverrides = append(ch.ApplicationOverrides, Application{
+							Id:        appId,
+							Threshold: c.AvailabilityObjectivePercentage,
+							Details:   "to " + c.Upstream.Name,
+						})
+					}
 				default:
 					klog.Warningln("unknown config type")
 				}
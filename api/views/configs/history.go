@@ -0,0 +1,93 @@
+package configs
+
+import (
+	"encoding/json"
+	"github.com/coroot/coroot/db"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"reflect"
+	"sort"
+)
+
+type Change struct {
+	Version       int                 `json:"version"`
+	Time          timeseries.Time     `json:"time"`
+	Scope         db.ConfigScope      `json:"scope"`
+	ApplicationId model.ApplicationId `json:"application_id"`
+	CheckId       model.CheckId       `json:"check_id"`
+	Author        string              `json:"author"`
+	Old           json.RawMessage     `json:"old"`
+	New           json.RawMessage     `json:"new"`
+	ChangedFields []string            `json:"changed_fields"`
+}
+
+func RenderHistory(changes []db.ConfigChange) []Change {
+	res := make([]Change, 0, len(changes))
+	for _, c := range changes {
+		if c.Scope == db.ConfigScopeProjectSettings {
+			c.Old, c.New = withoutIntegrations(c.Old), withoutIntegrations(c.New)
+		}
+		res = append(res, Change{
+			Version:       c.Version,
+			Time:          c.Time,
+			Scope:         c.Scope,
+			ApplicationId: c.ApplicationId,
+			CheckId:       c.CheckId,
+			Author:        c.Author,
+			Old:           rawOrNull(c.Old),
+			New:           rawOrNull(c.New),
+			ChangedFields: changedFields(c.Old, c.New),
+		})
+	}
+	return res
+}
+
+// withoutIntegrations hides integrations that might have been recorded with credentials.
+func withoutIntegrations(settings string) string {
+	if settings == "" {
+		return ""
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(settings), &fields); err != nil {
+		return ""
+	}
+	if _, ok := fields["integrations"]; !ok {
+		return settings
+	}
+	delete(fields, "integrations")
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func rawOrNull(s string) json.RawMessage {
+	if s == "" {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(s)
+}
+
+func changedFields(old, new string) []string {
+	o, n := map[string]any{}, map[string]any{}
+	if old != "" && json.Unmarshal([]byte(old), &o) != nil {
+		return nil
+	}
+	if new != "" && json.Unmarshal([]byte(new), &n) != nil {
+		return nil
+	}
+	var res []string
+	for k, v := range o {
+		if !reflect.DeepEqual(v, n[k]) {
+			res = append(res, k)
+		}
+	}
+	for k := range n {
+		if _, ok := o[k]; !ok {
+			res = append(res, k)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
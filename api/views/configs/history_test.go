@@ -0,0 +1,33 @@
+package configs
+
+import (
+	"github.com/coroot/coroot/db"
+	"github.com/coroot/coroot/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRenderHistory(t *testing.T) {
+	changes := RenderHistory([]db.ConfigChange{
+		{
+			Version: 2,
+			Scope:   db.ConfigScopeProjectSettings,
+			Old:     `{"integrations":{"slack":{"token":"xoxb-old"}},"custom_checks":null}`,
+			New:     `{"integrations":{"slack":{"token":"xoxb-new"}},"custom_checks":[]}`,
+		},
+		{
+			Version: 1,
+			Scope:   db.ConfigScopeCheckConfig,
+			CheckId: model.Checks.CPUNode.Id,
+			New:     `{"threshold":70}`,
+		},
+	})
+	require.Len(t, changes, 2)
+	assert.JSONEq(t, `{"custom_checks":null}`, string(changes[0].Old))
+	assert.JSONEq(t, `{"custom_checks":[]}`, string(changes[0].New))
+	assert.Equal(t, []string{"custom_checks"}, changes[0].ChangedFields)
+	assert.Equal(t, "null", string(changes[1].Old))
+	assert.JSONEq(t, `{"threshold":70}`, string(changes[1].New))
+	assert.Equal(t, []string{"threshold"}, changes[1].ChangedFields)
+}
@@ -0,0 +1,100 @@
+package incidents
+
+import (
+	"github.com/coroot/coroot/db"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+)
+
+type View struct {
+	Incidents []Incident `json:"incidents"`
+	Total     int        `json:"total"`
+}
+
+type Incident struct {
+	Key            string                                 `json:"key"`
+	ApplicationId  model.ApplicationId                    `json:"application_id"`
+	CheckId        model.CheckId                          `json:"check_id"`
+	Severity       model.Status                           `json:"severity"`
+	OpenedAt       timeseries.Time                        `json:"opened_at"`
+	ResolvedAt     timeseries.Time                        `json:"resolved_at"`
+	AcknowledgedAt timeseries.Time                        `json:"acknowledged_at"`
+	Duration       timeseries.Duration                    `json:"duration"`
+	Reports        []db.IncidentNotificationDetailsReport `json:"reports"`
+	Notifications  []Notification                         `json:"notifications"`
+}
+
+type Notification struct {
+	Destination db.IntegrationType            `json:"destination"`
+	Status      model.Status                  `json:"status"`
+	Reason      db.IncidentNotificationReason `json:"reason"`
+	Timestamp   timeseries.Time               `json:"timestamp"`
+	SentAt      timeseries.Time               `json:"sent_at"`
+	Delivered   bool                          `json:"delivered"`
+	Attempts    int                           `json:"attempts"`
+	LastError   string                        `json:"last_error"`
+	Discarded   bool                          `json:"discarded"`
+}
+
+type DeadLetter struct {
+	ApplicationId model.ApplicationId `json:"application_id"`
+	IncidentKey   string              `json:"incident_key"`
+	Notification
+}
+
+func RenderDeadLetters(notifications []db.IncidentNotification) []DeadLetter {
+	res := make([]DeadLetter, 0, len(notifications))
+	for _, n := range notifications {
+		res = append(res, DeadLetter{ApplicationId: n.ApplicationId, IncidentKey: n.IncidentKey, Notification: renderNotification(n)})
+	}
+	return res
+}
+
+func renderNotification(n db.IncidentNotification) Notification {
+	return Notification{
+		Destination: n.Destination,
+		Status:      n.Status,
+		Reason:      n.Reason,
+		Timestamp:   n.Timestamp,
+		SentAt:      n.SentAt,
+		Delivered:   !n.SentAt.IsZero(),
+		Attempts:    n.Attempts,
+		LastError:   n.LastError,
+		Discarded:   !n.DiscardedAt.IsZero(),
+	}
+}
+
+func Render(incidents []db.Incident, total int, notifications map[string][]db.IncidentNotification, now timeseries.Time) *View {
+	v := &View{Total: total}
+	for _, i := range incidents {
+		incident := Incident{
+			Key:            i.Key,
+			ApplicationId:  i.ApplicationId,
+			CheckId:        i.CheckId,
+			Severity:       i.Severity,
+			OpenedAt:       i.OpenedAt,
+			ResolvedAt:     i.ResolvedAt,
+			AcknowledgedAt: i.AcknowledgedAt,
+		}
+		if i.Resolved() {
+			incident.Duration = i.ResolvedAt.Sub(i.OpenedAt)
+		} else {
+			incident.Duration = now.Sub(i.OpenedAt)
+		}
+		reports := map[db.IncidentNotificationDetailsReport]bool{}
+		for _, n := range notifications[i.Key] {
+			incident.Notifications = append(incident.Notifications, renderNotification(n))
+			if n.Status <= model.OK || n.Details == nil {
+				continue
+			}
+			for _, r := range n.Details.Reports {
+				if !reports[r] {
+					reports[r] = true
+					incident.Reports = append(incident.Reports, r)
+				}
+			}
+		}
+		v.Incidents = append(v.Incidents, incident)
+	}
+	return v
+}
@@ -0,0 +1,170 @@
+package incidents
+
+import (
+	"fmt"
+	"github.com/coroot/coroot/db"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/coroot/coroot/utils"
+	"sort"
+	"strings"
+)
+
+type TimelineEventType string
+
+const (
+	TimelineEventIncident         TimelineEventType = "incident"
+	TimelineEventDeployment       TimelineEventType = "deployment"
+	TimelineEventApplication      TimelineEventType = "application_event"
+	TimelineEventCheck            TimelineEventType = "check"
+	TimelineEventUpstreamIncident TimelineEventType = "upstream_incident"
+)
+
+type Timeline struct {
+	Incident Incident        `json:"incident"`
+	From     timeseries.Time `json:"from"`
+	To       timeseries.Time `json:"to"`
+	Events   []TimelineEvent `json:"events"`
+	Checks   []TimelineCheck `json:"checks"`
+}
+
+type TimelineEvent struct {
+	Timestamp     timeseries.Time     `json:"timestamp"`
+	Type          TimelineEventType   `json:"type"`
+	ApplicationId model.ApplicationId `json:"application_id"`
+	Status        model.Status        `json:"status"`
+	Text          string              `json:"text"`
+}
+
+type TimelineCheck struct {
+	Report  model.AuditReportName `json:"report"`
+	Check   string                `json:"check"`
+	Status  model.Status          `json:"status"`
+	Message string                `json:"message"`
+}
+
+func RenderTimeline(w *model.World, app *model.Application, incident db.Incident, notifications []db.IncidentNotification, checkTransitions []db.IncidentCheckTransition, upstreamIncidents map[model.ApplicationId][]db.Incident) *Timeline {
+	now := w.Ctx.To
+	if incident.Resolved() {
+		now = incident.ResolvedAt
+	}
+	v := Render([]db.Incident{incident}, 1, map[string][]db.IncidentNotification{incident.Key: notifications}, now)
+	t := &Timeline{Incident: v.Incidents[0], From: w.Ctx.From, To: w.Ctx.To}
+
+	t.add(incident.OpenedAt, TimelineEventIncident, app.Id, incident.Severity, "incident opened")
+	if incident.Acknowledged() {
+		t.add(incident.AcknowledgedAt, TimelineEventIncident, app.Id, incident.Severity, "incident acknowledged")
+	}
+	if incident.Resolved() {
+		t.add(incident.ResolvedAt, TimelineEventIncident, app.Id, model.OK, "incident resolved")
+	}
+
+	for _, d := range app.Deployments {
+		if d.StartedAt.Before(t.From) || d.StartedAt.After(t.To) {
+			continue
+		}
+		t.add(d.StartedAt, TimelineEventDeployment, app.Id, model.INFO, "rollout of "+d.Version()+" started")
+		if !d.FinishedAt.IsZero() {
+			t.add(d.FinishedAt, TimelineEventDeployment, app.Id, model.INFO, "rollout of "+d.Version()+" finished")
+		}
+	}
+
+	for _, e := range app.Events {
+		ts := e.Start
+		if ts.IsZero() {
+			ts = t.From
+		}
+		var text string
+		switch e.Type {
+		case model.ApplicationEventTypeSwitchover:
+			text = "switchover"
+		case model.ApplicationEventTypeRollout:
+			text = "rollout"
+		case model.ApplicationEventTypeInstanceDown:
+			text = "instance down"
+		case model.ApplicationEventTypeInstanceUp:
+			text = "instance up"
+		}
+		if e.Details != "" {
+			text += ": " + e.Details
+		}
+		t.add(ts, TimelineEventApplication, app.Id, model.INFO, text)
+	}
+
+	for _, ct := range checkTransitions {
+		text := fmt.Sprintf("%s / %s: ", ct.Report, ct.Title)
+		if ct.Status == model.OK {
+			text += "ok"
+		} else {
+			text += ct.Message
+		}
+		t.add(ct.Timestamp, TimelineEventCheck, app.Id, ct.Status, text)
+	}
+
+	for appId, incidents := range upstreamIncidents {
+		for _, i := range incidents {
+			t.add(i.OpenedAt, TimelineEventUpstreamIncident, appId, i.Severity, "upstream incident opened: "+appId.Name)
+			if i.Resolved() && !i.ResolvedAt.After(t.To) {
+				t.add(i.ResolvedAt, TimelineEventUpstreamIncident, appId, model.OK, "upstream incident resolved: "+appId.Name)
+			}
+		}
+	}
+
+	sort.SliceStable(t.Events, func(i, j int) bool {
+		return t.Events[i].Timestamp.Before(t.Events[j].Timestamp)
+	})
+
+	for _, r := range app.Reports {
+		for _, ch := range r.Checks {
+			if ch.Status < model.WARNING {
+				continue
+			}
+			t.Checks = append(t.Checks, TimelineCheck{Report: r.Name, Check: ch.Title, Status: ch.Status, Message: ch.Message})
+		}
+	}
+	return t
+}
+
+func (t *Timeline) add(ts timeseries.Time, typ TimelineEventType, appId model.ApplicationId, status model.Status, text string) {
+	t.Events = append(t.Events, TimelineEvent{Timestamp: ts, Type: typ, ApplicationId: appId, Status: status, Text: text})
+}
+
+func (t *Timeline) Markdown() string {
+	buf := &strings.Builder{}
+	i := t.Incident
+	fmt.Fprintf(buf, "# %s incident %s\n\n", i.ApplicationId.Name, i.Key)
+	fmt.Fprintf(buf, "- Application: `%s`\n", i.ApplicationId.String())
+	fmt.Fprintf(buf, "- Severity: %s\n", i.Severity.String())
+	fmt.Fprintf(buf, "- Opened: %s\n", formatTime(i.OpenedAt))
+	if i.ResolvedAt.IsZero() {
+		buf.WriteString("- Resolved: ongoing\n")
+	} else {
+		fmt.Fprintf(buf, "- Resolved: %s\n", formatTime(i.ResolvedAt))
+	}
+	fmt.Fprintf(buf, "- Duration: %s\n", utils.FormatDuration(i.Duration, 2))
+
+	buf.WriteString("\n## Timeline\n\n")
+	buf.WriteString("| Time | Event | Application | Status |\n")
+	buf.WriteString("| --- | --- | --- | --- |\n")
+	for _, e := range t.Events {
+		fmt.Fprintf(buf, "| %s | %s | %s | %s |\n", formatTime(e.Timestamp), escapeMarkdown(e.Text), e.ApplicationId.Name, e.Status.String())
+	}
+
+	if len(t.Checks) > 0 {
+		buf.WriteString("\n## Failed checks\n\n")
+		for _, ch := range t.Checks {
+			fmt.Fprintf(buf, "- **%s / %s** (%s): %s\n", ch.Report, ch.Check, ch.Status.String(), escapeMarkdown(ch.Message))
+		}
+	}
+	return buf.String()
+}
+
+func formatTime(t timeseries.Time) string {
+	return t.ToStandard().UTC().Format("2006-01-02 15:04:05 UTC")
+}
+
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
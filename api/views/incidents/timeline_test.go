@@ -0,0 +1,11 @@
+package incidents
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEscapeMarkdown(t *testing.T) {
+	assert.Equal(t, "a \\| b", escapeMarkdown("a | b"))
+	assert.Equal(t, "line1<br>line2<br>line3<br>line4", escapeMarkdown("line1\nline2\r\nline3\rline4"))
+}
@@ -0,0 +1,257 @@
+package slo
+
+import (
+	"encoding/csv"
+	"github.com/coroot/coroot/db"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"io"
+	"sort"
+	"strconv"
+)
+
+type View struct {
+	Period       string          `json:"period"`
+	From         timeseries.Time `json:"from"`
+	To           timeseries.Time `json:"to"`
+	Applications []*Application  `json:"applications"`
+}
+
+type Application struct {
+	Id           model.ApplicationId `json:"id"`
+	Availability []*Objective        `json:"availability"`
+	Latency      []*Objective        `json:"latency"`
+}
+
+type Objective struct {
+	Name                 string      `json:"name"`
+	Objective            float32     `json:"objective"`
+	Achieved             *float32    `json:"achieved"`
+	Compliant            bool        `json:"compliant"`
+	DataCoverage         float32     `json:"data_coverage"`
+	TotalRequests        float32     `json:"total_requests"`
+	BadRequests          float32     `json:"bad_requests"`
+	ErrorBudgetConsumed  float32     `json:"error_budget_consumed"`
+	ErrorBudgetRemaining float32     `json:"error_budget_remaining"`
+	Incidents            []*Incident `json:"incidents"`
+}
+
+type Incident struct {
+	Key                 string          `json:"key"`
+	OpenedAt            timeseries.Time `json:"opened_at"`
+	ResolvedAt          timeseries.Time `json:"resolved_at"`
+	Severity            model.Status    `json:"severity"`
+	BadRequests         float32         `json:"bad_requests"`
+	ErrorBudgetConsumed float32         `json:"error_budget_consumed"`
+}
+
+func Render(w *model.World, period string, from, to timeseries.Time, incidents []db.Incident, checkTransitions map[string][]db.IncidentCheckTransition) *View {
+	v := &View{Period: period, From: from, To: to}
+
+	incidentsByApp := map[model.ApplicationId][]db.Incident{}
+	for _, i := range incidents {
+		incidentsByApp[i.ApplicationId] = append(incidentsByApp[i.ApplicationId], i)
+	}
+
+	for _, app := range w.Applications {
+		a := &Application{Id: app.Id}
+		for _, sli := range app.AvailabilitySLIs {
+			failed := sli.FailedRequests
+			if failed.IsEmpty() {
+				failed = sli.TotalRequests.WithNewValue(0)
+			}
+			o := calcObjective(sli.Config.ObjectivePercentage, sli.TotalRequests, failed, from, to, w.Ctx.Step,
+				appIncidents(incidentsByApp[app.Id], model.Checks.SLOAvailability.Id, checkTransitions))
+			o.Name = sli.Config.Name
+			a.Availability = append(a.Availability, o)
+		}
+		for _, sli := range app.LatencySLIs {
+			total, fast := sli.GetTotalAndFast(false)
+			if total.IsEmpty() {
+				continue
+			}
+			if fast.IsEmpty() {
+				fast = total.WithNewValue(0)
+			}
+			o := calcObjective(sli.Config.ObjectivePercentage, total, timeseries.Sub(total, fast.Map(timeseries.NanToZero)), from, to, w.Ctx.Step,
+				appIncidents(incidentsByApp[app.Id], model.Checks.SLOLatency.Id, checkTransitions))
+			o.Name = sli.Config.Name
+			a.Latency = append(a.Latency, o)
+		}
+		if len(a.Availability) == 0 && len(a.Latency) == 0 {
+			continue
+		}
+		v.Applications = append(v.Applications, a)
+	}
+	sort.Slice(v.Applications, func(i, j int) bool {
+		return v.Applications[i].Id.String() < v.Applications[j].Id.String()
+	})
+	return v
+}
+
+func (v *View) CSV(out io.Writer) error {
+	w := csv.NewWriter(out)
+	_ = w.Write([]string{
+		"application", "slo", "from", "to", "objective", "achieved", "compliant", "data_coverage",
+		"total_requests", "bad_requests", "error_budget_consumed", "error_budget_remaining", "incidents",
+	})
+	for _, a := range v.Applications {
+		for _, o := range objectives(a) {
+			_ = w.Write([]string{
+				a.Id.String(), o.name, v.From.ToStandard().UTC().Format("2006-01-02"), v.To.ToStandard().UTC().Format("2006-01-02"),
+				formatFloat(o.o.Objective), formatOptionalFloat(o.o.Achieved), strconv.FormatBool(o.o.Compliant), formatFloat(o.o.DataCoverage),
+				formatFloat(o.o.TotalRequests), formatFloat(o.o.BadRequests),
+				formatFloat(o.o.ErrorBudgetConsumed), formatFloat(o.o.ErrorBudgetRemaining),
+				strconv.Itoa(len(o.o.Incidents)),
+			})
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+type namedObjective struct {
+	name string
+	o    *Objective
+}
+
+func objectives(a *Application) []namedObjective {
+	var res []namedObjective
+	for _, o := range a.Availability {
+		res = append(res, namedObjective{name: objectiveName("availability", o.Name), o: o})
+	}
+	for _, o := range a.Latency {
+		res = append(res, namedObjective{name: objectiveName("latency", o.Name), o: o})
+	}
+	return res
+}
+
+func objectiveName(kind, name string) string {
+	if name == "" {
+		return kind
+	}
+	return kind + ": " + name
+}
+
+type attributedIncident struct {
+	db.Incident
+	// the incident has no recorded check transitions, so it's attributed to the objective only if it consumed its budget
+	unattributed bool
+}
+
+func calcObjective(objective float32, total, bad *timeseries.TimeSeries, from, to timeseries.Time, step timeseries.Duration, incidents []attributedIncident) *Objective {
+	o := &Objective{Objective: objective}
+	o.DataCoverage = coverage(total, from, to, step)
+	o.TotalRequests = sum(total, from, to, step)
+	o.BadRequests = sum(bad, from, to, step)
+	if o.DataCoverage > 0 {
+		achieved := float32(100)
+		if o.TotalRequests > 0 {
+			achieved = (o.TotalRequests - o.BadRequests) / o.TotalRequests * 100
+		}
+		o.Achieved = &achieved
+		o.Compliant = achieved >= objective
+	}
+	budget := o.TotalRequests * (1 - objective/100)
+	o.ErrorBudgetConsumed = budgetPercentage(o.BadRequests, budget)
+	o.ErrorBudgetRemaining = 100 - o.ErrorBudgetConsumed
+	for _, i := range incidents {
+		incidentTo := to
+		if i.Resolved() && i.ResolvedAt.Before(to) {
+			incidentTo = i.ResolvedAt
+		}
+		incidentFrom := i.OpenedAt
+		if incidentFrom.Before(from) {
+			incidentFrom = from
+		}
+		incidentBad := sum(bad, incidentFrom, incidentTo, step)
+		if i.unattributed && incidentBad == 0 {
+			continue
+		}
+		o.Incidents = append(o.Incidents, &Incident{
+			Key:                 i.Key,
+			OpenedAt:            i.OpenedAt,
+			ResolvedAt:          i.ResolvedAt,
+			Severity:            i.Severity,
+			BadRequests:         incidentBad,
+			ErrorBudgetConsumed: budgetPercentage(incidentBad, budget),
+		})
+	}
+	return o
+}
+
+// appIncidents returns the incidents related to the given SLO check.
+// Application-level SLO incidents are attributed to a check based on the check transitions recorded during the incident.
+func appIncidents(incidents []db.Incident, checkId model.CheckId, checkTransitions map[string][]db.IncidentCheckTransition) []attributedIncident {
+	var res []attributedIncident
+	for _, i := range incidents {
+		switch i.CheckId {
+		case checkId:
+			res = append(res, attributedIncident{Incident: i})
+		case "":
+			transitions := checkTransitions[i.Key]
+			if len(transitions) == 0 {
+				res = append(res, attributedIncident{Incident: i, unattributed: true})
+				continue
+			}
+			for _, t := range transitions {
+				if t.CheckId == checkId && t.Status >= model.WARNING {
+					res = append(res, attributedIncident{Incident: i})
+					break
+				}
+			}
+		}
+	}
+	return res
+}
+
+func coverage(ts *timeseries.TimeSeries, from, to timeseries.Time, step timeseries.Duration) float32 {
+	points := int(to.Sub(from) / step)
+	if points <= 0 {
+		return 0
+	}
+	covered := 0
+	iter := ts.Iter()
+	for iter.Next() {
+		t, v := iter.Value()
+		if t.Before(from) || !t.Before(to) || timeseries.IsNaN(v) {
+			continue
+		}
+		covered++
+	}
+	return float32(covered) / float32(points) * 100
+}
+
+func sum(ts *timeseries.TimeSeries, from, to timeseries.Time, step timeseries.Duration) float32 {
+	res := ts.Reduce(func(t timeseries.Time, accumulator, v float32) float32 {
+		if t.Before(from) || !t.Before(to) {
+			return accumulator
+		}
+		return timeseries.NanSum(t, accumulator, v)
+	})
+	if timeseries.IsNaN(res) {
+		return 0
+	}
+	return res * float32(step)
+}
+
+func budgetPercentage(bad, budget float32) float32 {
+	if budget <= 0 {
+		if bad > 0 {
+			return 100
+		}
+		return 0
+	}
+	return bad / budget * 100
+}
+
+func formatOptionalFloat(v *float32) string {
+	if v == nil {
+		return ""
+	}
+	return formatFloat(*v)
+}
+
+func formatFloat(v float32) string {
+	return strconv.FormatFloat(float64(v), 'f', 4, 32)
+}
@@ -0,0 +1,139 @@
+package slo
+
+import (
+	"github.com/coroot/coroot/db"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCalcObjective(t *testing.T) {
+	const step = timeseries.Minute
+	nan := timeseries.NaN
+	series := func(values ...float32) *timeseries.TimeSeries {
+		return timeseries.NewWithData(0, step, values)
+	}
+	repeat := func(v float32, n int) []float32 {
+		res := make([]float32, n)
+		for i := range res {
+			res[i] = v
+		}
+		return res
+	}
+	from, to := timeseries.Time(0), timeseries.Time(0).Add(10*step)
+
+	tests := []struct {
+		name       string
+		total, bad *timeseries.TimeSeries
+		incidents  []attributedIncident
+		achieved   *float32
+		compliant  bool
+		coverage   float32
+		consumed   float32
+		incidentsN int
+	}{
+		{
+			name:      "compliant",
+			total:     series(repeat(10, 10)...),
+			bad:       series(repeat(0.01, 10)...),
+			achieved:  ptr(99.9),
+			compliant: true,
+			coverage:  100,
+			consumed:  10,
+		},
+		{
+			name:     "not compliant",
+			total:    series(repeat(10, 10)...),
+			bad:      series(repeat(0.2, 10)...),
+			achieved: ptr(98),
+			coverage: 100,
+			consumed: 200,
+		},
+		{
+			name:      "partial coverage",
+			total:     series(append(repeat(nan, 5), repeat(10, 5)...)...),
+			bad:       series(append(repeat(nan, 5), repeat(0.01, 5)...)...),
+			achieved:  ptr(99.9),
+			compliant: true,
+			coverage:  50,
+			consumed:  10,
+		},
+		{
+			name: "no data",
+		},
+		{
+			name:      "no traffic",
+			total:     series(repeat(0, 10)...),
+			bad:       series(repeat(0, 10)...),
+			achieved:  ptr(100),
+			compliant: true,
+			coverage:  100,
+		},
+		{
+			name:  "incidents",
+			total: series(repeat(10, 10)...),
+			bad:   series(0, 0, 0.05, 0.05, 0, 0, 0, 0, 0, 0),
+			incidents: []attributedIncident{
+				{Incident: db.Incident{Key: "i1", OpenedAt: from.Add(2 * step), ResolvedAt: from.Add(4 * step)}},
+				{Incident: db.Incident{Key: "i2", OpenedAt: from.Add(6 * step), ResolvedAt: from.Add(8 * step)}},
+				{Incident: db.Incident{Key: "i3", OpenedAt: from.Add(6 * step), ResolvedAt: from.Add(8 * step)}, unattributed: true},
+				{Incident: db.Incident{Key: "i4", OpenedAt: from.Add(-step)}, unattributed: true},
+			},
+			achieved:   ptr(99.9),
+			compliant:  true,
+			coverage:   100,
+			consumed:   10,
+			incidentsN: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := calcObjective(99, tt.total, tt.bad, from, to, step, tt.incidents)
+			if tt.achieved == nil {
+				assert.Nil(t, o.Achieved)
+			} else {
+				require.NotNil(t, o.Achieved)
+				assert.InDelta(t, *tt.achieved, *o.Achieved, 0.001)
+			}
+			assert.Equal(t, tt.compliant, o.Compliant)
+			assert.InDelta(t, tt.coverage, o.DataCoverage, 0.001)
+			assert.InDelta(t, tt.consumed, o.ErrorBudgetConsumed, 0.001)
+			assert.Len(t, o.Incidents, tt.incidentsN)
+		})
+	}
+}
+
+func TestAppIncidents(t *testing.T) {
+	availability, latency := model.Checks.SLOAvailability.Id, model.Checks.SLOLatency.Id
+	incidents := []db.Incident{
+		{Key: "availability"},
+		{Key: "latency"},
+		{Key: "both"},
+		{Key: "legacy"},
+		{Key: "cpu", CheckId: model.Checks.CPUNode.Id},
+	}
+	transitions := map[string][]db.IncidentCheckTransition{
+		"availability": {{CheckId: availability, Status: model.CRITICAL}, {CheckId: model.Checks.CPUNode.Id, Status: model.WARNING}},
+		"latency":      {{CheckId: latency, Status: model.WARNING}, {CheckId: latency, Status: model.OK}},
+		"both":         {{CheckId: availability, Status: model.WARNING}, {CheckId: latency, Status: model.CRITICAL}},
+	}
+	keys := func(is []attributedIncident) []string {
+		var res []string
+		for _, i := range is {
+			key := i.Key
+			if i.unattributed {
+				key += "?"
+			}
+			res = append(res, key)
+		}
+		return res
+	}
+	assert.Equal(t, []string{"availability", "both", "legacy?"}, keys(appIncidents(incidents, availability, transitions)))
+	assert.Equal(t, []string{"latency", "both", "legacy?"}, keys(appIncidents(incidents, latency, transitions)))
+}
+
+func ptr(v float32) *float32 {
+	return &v
+}
@@ -5,12 +5,14 @@ import (
 	"github.com/coroot/coroot/api/views/application"
 	"github.com/coroot/coroot/api/views/categories"
 	"github.com/coroot/coroot/api/views/configs"
+	"github.com/coroot/coroot/api/views/incidents"
 	"github.com/coroot/coroot/api/views/integrations"
 	"github.com/coroot/coroot/api/views/node"
 	"github.com/coroot/coroot/api/views/overview"
 	"github.com/coroot/coroot/api/views/profile"
 	"github.com/coroot/coroot/api/views/project"
 	"github.com/coroot/coroot/api/views/search"
+	"github.com/coroot/coroot/api/views/slo"
 	"github.com/coroot/coroot/cache"
 	"github.com/coroot/coroot/db"
 	"github.com/coroot/coroot/model"
@@ -46,6 +48,10 @@ func Configs(checkConfigs model.CheckConfigs) *configs.View {
 	return configs.Render(checkConfigs)
 }
 
+func ConfigHistory(changes []db.ConfigChange) []configs.Change {
+	return configs.RenderHistory(changes)
+}
+
 func Categories(p *db.Project) *categories.View {
 	return categories.Render(p)
 }
@@ -53,3 +59,19 @@ func Categories(p *db.Project) *categories.View {
 func Integrations(p *db.Project) *integrations.View {
 	return integrations.Render(p)
 }
+
+func Incidents(list []db.Incident, total int, notifications map[string][]db.IncidentNotification, now timeseries.Time) *incidents.View {
+	return incidents.Render(list, total, notifications, now)
+}
+
+func IncidentTimeline(w *model.World, app *model.Application, incident db.Incident, notifications []db.IncidentNotification, checkTransitions []db.IncidentCheckTransition, upstreamIncidents map[model.ApplicationId][]db.Incident) *incidents.Timeline {
+	return incidents.RenderTimeline(w, app, incident, notifications, checkTransitions, upstreamIncidents)
+}
+
+func DeadLetters(notifications []db.IncidentNotification) []incidents.DeadLetter {
+	return incidents.RenderDeadLetters(notifications)
+}
+
+func SLOCompliance(w *model.World, period string, from, to timeseries.Time, incidents []db.Incident, checkTransitions map[string][]db.IncidentCheckTransition) *slo.View {
+	return slo.Render(w, period, from, to, incidents, checkTransitions)
+}
@@ -30,9 +30,15 @@ func Audit(w *model.World, p *db.Project) {
 		a.network()
 		a.postgres()
 		a.redis()
+		a.mysql()
+		a.mongodb()
+		a.kafka()
+		a.elasticsearch()
+		a.rabbitmq()
 		a.jvm()
 		a.logs()
 		a.deployments()
+		a.custom()
 
 		for _, r := range a.reports {
 			widgets := enrichWidgets(r.Widgets, app.Events)
@@ -48,7 +54,8 @@ func Audit(w *model.World, p *db.Project) {
 				}
 			}
 			switch r.Name {
-			case model.AuditReportPostgres, model.AuditReportRedis, model.AuditReportInstances, model.AuditReportSLO:
+			case model.AuditReportPostgres, model.AuditReportRedis, model.AuditReportMysql, model.AuditReportMongodb, model.AuditReportKafka,
+				model.AuditReportElasticsearch, model.AuditReportRabbitmq, model.AuditReportInstances, model.AuditReportSLO:
 				if app.Status < r.Status {
 					app.Status = r.Status
 				}
@@ -60,6 +67,8 @@ func Audit(w *model.World, p *db.Project) {
 			app.AddReport(model.AuditReportProfiling, &model.Widget{Profile: &model.Profile{ApplicationId: app.Id}, Width: "100%"})
 		}
 	}
+
+	rootCauseHints(w)
 }
 
 func (a *appAuditor) addReport(name model.AuditReportName) *model.AuditReport {
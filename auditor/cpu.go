@@ -28,10 +28,11 @@ func (a *appAuditor) cpu(ncs nodeConsumersByNode) {
 			report.GetOrCreateChartInGroup("CPU delay of container <selector>, seconds/second", c.Name).AddSeries(i.Name, c.CpuDelay)
 			report.GetOrCreateChartInGroup("Throttled time of container <selector>, seconds/second", c.Name).AddSeries(i.Name, c.ThrottledTime)
 
-			usage := c.CpuUsage.Last() / c.CpuLimit.Last()
-			if usage > containerCpuCheck.Threshold {
+			usage := timeseries.Div(c.CpuUsage, c.CpuLimit)
+			if containerCpuCheck.Exceeds(usage, containerCpuCheck.Threshold) {
 				usageChart.Feature()
 				containerCpuCheck.AddItem("%s@%s", c.Name, i.Name)
+				containerCpuCheck.UpdateSeverity(usage.Last())
 			}
 		}
 		if node := i.Node; i.Node != nil {
@@ -51,9 +52,10 @@ func (a *appAuditor) cpu(ncs nodeConsumersByNode) {
 					SetThreshold("total", node.CpuCapacity).
 					AddMany(ncs.get(node).cpu, 5, timeseries.Max)
 
-				if i.Node.CpuUsagePercent.Last() > nodeCpuCheck.Threshold {
+				if nodeCpuCheck.Exceeds(i.Node.CpuUsagePercent, nodeCpuCheck.Threshold) {
 					consumersChart.Feature()
 					nodeCpuCheck.AddItem(i.Node.Name.Value())
+					nodeCpuCheck.UpdateSeverity(i.Node.CpuUsagePercent.Last())
 				}
 			}
 		}
@@ -0,0 +1,73 @@
+package auditor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"sort"
+	"strings"
+)
+
+func (a *appAuditor) custom() {
+	if len(a.app.CustomChecks) == 0 {
+		return
+	}
+	report := a.addReport(model.AuditReportCustom)
+	for _, d := range a.app.CustomChecks {
+		check := report.CreateCheck(d.Check.CheckConfig())
+		if d.Error != "" {
+			check.SetStatus(model.UNKNOWN, "failed to get data: %s", d.Error)
+			continue
+		}
+		if len(d.Values) == 0 {
+			check.SetStatus(model.UNKNOWN, "no data")
+			continue
+		}
+
+		chart := report.GetOrCreateChart(d.Check.Title)
+		for _, mv := range d.Values {
+			chart.AddSeries(customSeriesName(mv.Labels), mv.Values)
+		}
+		if d.Check.Type != model.CheckTypeEventBased {
+			chart.Threshold = &model.Series{
+				Name:  "threshold",
+				Color: "red",
+				Data:  d.Values[0].Values.WithNewValue(d.Check.Threshold),
+			}
+		}
+
+		switch d.Check.Type {
+		case model.CheckTypeValueBased:
+			value := timeseries.NaN
+			for _, mv := range d.Values {
+				if _, v := mv.Values.LastNotNull(); !timeseries.IsNaN(v) && (timeseries.IsNaN(value) || v > value) {
+					value = v
+				}
+			}
+			if !timeseries.IsNaN(value) {
+				check.SetValue(value)
+			}
+		case model.CheckTypeItemBased:
+			for _, mv := range d.Values {
+				if _, v := mv.Values.LastNotNull(); v > d.Check.Threshold {
+					check.AddItem(customSeriesName(mv.Labels))
+				}
+			}
+		case model.CheckTypeEventBased:
+			if sum := d.Sum().Reduce(timeseries.NanSum); !timeseries.IsNaN(sum) {
+				check.Inc(int64(sum))
+			}
+		}
+	}
+}
+
+func customSeriesName(ls model.Labels) string {
+	if len(ls) == 0 {
+		return "value"
+	}
+	parts := make([]string, 0, len(ls))
+	for k, v := range ls {
+		parts = append(parts, k+"="+v)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
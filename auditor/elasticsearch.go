@@ -0,0 +1,120 @@
+package auditor
+
+import (
+	"fmt"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/coroot/coroot/utils"
+)
+
+func (a *appAuditor) elasticsearch() {
+	if !a.app.IsElasticsearch() {
+		return
+	}
+
+	report := a.addReport(model.AuditReportElasticsearch)
+	healthCheck := report.CreateCheck(model.Checks.ElasticsearchHealth)
+	latencyCheck := report.CreateCheck(model.Checks.ElasticsearchLatency)
+	rejectionsCheck := report.CreateCheck(model.Checks.ElasticsearchRejections)
+
+	var clustersTable, nodesTable *model.Table
+	clusters := map[string]bool{}
+	for _, i := range a.app.Instances {
+		es := i.Elasticsearch
+		if es == nil {
+			continue
+		}
+		if es.Health.Value() != "" && !clusters[es.Cluster.Value()] {
+			clusters[es.Cluster.Value()] = true
+			healthCell := model.NewTableCell(es.Health.Value())
+			switch score := es.HealthScore(); {
+			case score > healthCheck.Threshold:
+				healthCheck.AddItem(es.Cluster.Value())
+				healthCheck.UpdateSeverity(score)
+				healthCell.SetStatus(model.WARNING, es.Health.Value())
+			case score == 0:
+				healthCell.SetStatus(model.OK, es.Health.Value())
+			}
+			if clustersTable == nil {
+				clustersTable = report.CreateTable("Cluster", "Health", "Unassigned shards")
+			}
+			clustersTable.AddRow(
+				model.NewTableCell(es.Cluster.Value()),
+				healthCell,
+				model.NewTableCell(utils.FormatFloat(es.UnassignedShards.Last())),
+			)
+			report.
+				GetOrCreateChart("Unassigned shards").
+				AddSeries(es.Cluster.Value(), es.UnassignedShards)
+		}
+		if es.HeapUsed.IsEmpty() && es.SearchRate.IsEmpty() && es.IndexingRate.IsEmpty() {
+			continue
+		}
+
+		report.
+			GetOrCreateChartInGroup("Search latency <selector>, seconds", "overview").
+			Feature().
+			AddSeries(i.Name, es.SearchLatency)
+		report.
+			GetOrCreateChartInGroup("Indexing latency <selector>, seconds", "overview").
+			AddSeries(i.Name, es.IndexingLatency)
+		report.
+			GetOrCreateChart("Requests per second").
+			AddSeries(i.Name+" search", es.SearchRate).
+			AddSeries(i.Name+" indexing", es.IndexingRate)
+		report.
+			GetOrCreateChartInGroup("JVM heap <selector>, bytes", i.Name).
+			AddSeries("used", es.HeapUsed).
+			SetThreshold("max", es.HeapMax)
+
+		rejected := map[string]model.SeriesData{}
+		for pool, ts := range es.RejectedTasks {
+			rejected[pool] = ts
+		}
+		report.
+			GetOrCreateChartInGroup("Rejected tasks <selector>, per second", i.Name).
+			Column().
+			AddMany(rejected, 5, timeseries.NanSum)
+
+		if i.IsObsolete() {
+			continue
+		}
+
+		searchLatency, indexingLatency := es.SearchLatency.Last(), es.IndexingLatency.Last()
+		if searchLatency > latencyCheck.Threshold || indexingLatency > latencyCheck.Threshold {
+			latencyCheck.AddItem(i.Name)
+			if searchLatency > indexingLatency {
+				latencyCheck.UpdateSeverity(searchLatency)
+			} else {
+				latencyCheck.UpdateSeverity(indexingLatency)
+			}
+		}
+
+		rejectedCell := model.NewTableCell()
+		total := timeseries.NewAggregate(timeseries.NanSum)
+		for _, ts := range es.RejectedTasks {
+			total.Add(ts)
+		}
+		if v := total.Get().Reduce(timeseries.NanSum); !timeseries.IsNaN(v) {
+			v *= float32(a.w.Ctx.Step)
+			rejectionsCheck.Inc(int64(v))
+			rejectedCell.SetValue(fmt.Sprintf("%.0f", v))
+		}
+
+		heapCell := model.NewTableCell()
+		if used, max := es.HeapUsed.Last(), es.HeapMax.Last(); used > 0 && max > 0 {
+			heapCell.SetValue(fmt.Sprintf("%.0f%%", used/max*100))
+		}
+
+		if nodesTable == nil {
+			nodesTable = report.CreateTable("Node", "Heap", "Search latency", "Indexing latency", "Rejected tasks")
+		}
+		nodesTable.AddRow(
+			model.NewTableCell(i.Name),
+			heapCell,
+			model.NewTableCell(utils.FormatFloat(searchLatency*1000)).SetUnit("ms"),
+			model.NewTableCell(utils.FormatFloat(indexingLatency*1000)).SetUnit("ms"),
+			rejectedCell,
+		)
+	}
+}
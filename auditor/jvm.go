@@ -41,6 +41,7 @@ func (a *appAuditor) jvm() {
 		)
 		if i.Jvm.SafepointTime.Last() > safepointTime.Threshold {
 			safepointTime.AddItem(i.Name)
+			safepointTime.UpdateSeverity(i.Jvm.SafepointTime.Last())
 		}
 	}
 }
@@ -0,0 +1,195 @@
+package auditor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/coroot/coroot/utils"
+	"net"
+	"sort"
+	"strings"
+)
+
+func (a *appAuditor) kafka() {
+	if a.app.IsKafka() {
+		a.kafkaBrokers()
+		return
+	}
+	a.kafkaConsumers()
+}
+
+func (a *appAuditor) kafkaBrokers() {
+	report := a.addReport(model.AuditReportKafka)
+	availabilityCheck := report.CreateCheck(model.Checks.KafkaAvailability)
+	underReplicatedCheck := report.CreateCheck(model.Checks.KafkaUnderReplicatedPartitions)
+	offlineCheck := report.CreateCheck(model.Checks.KafkaOfflinePartitions)
+
+	k := kafkaClusterMetrics(a.app)
+
+	underReplicated := map[string]model.SeriesData{}
+	underReplicatedTopics := 0
+	for topic, ts := range k.UnderReplicatedPartitions {
+		underReplicated[topic] = ts
+		if ts.Last() > 0 {
+			underReplicatedCheck.AddItem(topic)
+			underReplicatedTopics++
+		}
+	}
+	underReplicatedCheck.UpdateSeverity(float32(underReplicatedTopics))
+	offline := map[string]model.SeriesData{}
+	offlineTopics := 0
+	for topic, ts := range k.OfflinePartitions {
+		offline[topic] = ts
+		if ts.Last() > 0 {
+			offlineCheck.AddItem(topic)
+			offlineTopics++
+		}
+	}
+	offlineCheck.UpdateSeverity(float32(offlineTopics))
+	report.
+		GetOrCreateChart("Under-replicated partitions").
+		Stacked().
+		AddMany(underReplicated, 5, timeseries.Max)
+	report.
+		GetOrCreateChart("Offline partitions").
+		Stacked().
+		AddMany(offline, 5, timeseries.Max)
+
+	lag := map[string]model.SeriesData{}
+	for key, ts := range k.ConsumerGroupLag {
+		lag[key.String()] = ts
+	}
+	report.
+		GetOrCreateChart("Consumer lag, messages").
+		AddMany(lag, 5, timeseries.Max)
+
+	for _, i := range a.app.Instances {
+		if i.IsObsolete() || !i.ApplicationTypes()[model.ApplicationTypeKafka] {
+			continue
+		}
+		status := model.NewTableCell()
+		if len(k.Brokers) > 0 {
+			status.SetStatus(model.OK, "up")
+			if !kafkaBrokerRegistered(i, k.Brokers) {
+				availabilityCheck.AddItem(i.Name)
+				status.SetStatus(model.WARNING, "not in the cluster metadata")
+			}
+		}
+		report.GetOrCreateTable("Broker", "Status").AddRow(model.NewTableCell(i.Name), status)
+	}
+}
+
+func (a *appAuditor) kafkaConsumers() {
+	lags := map[model.KafkaConsumerGroupKey]*timeseries.TimeSeries{}
+	seen := map[model.ApplicationId]bool{}
+	for _, i := range a.app.Instances {
+		for _, u := range i.Upstreams {
+			if u.RemoteInstance == nil || seen[u.RemoteInstance.OwnerId] {
+				continue
+			}
+			seen[u.RemoteInstance.OwnerId] = true
+			broker := a.w.GetApplication(u.RemoteInstance.OwnerId)
+			if broker == nil || !broker.IsKafka() {
+				continue
+			}
+			for key, ts := range kafkaClusterMetrics(broker).ConsumerGroupLag {
+				if kafkaConsumerGroupMatches(a.app, key.Group) {
+					lags[key] = ts
+				}
+			}
+		}
+	}
+	if len(lags) == 0 {
+		return
+	}
+
+	report := a.addReport(model.AuditReportKafka)
+	lagCheck := report.CreateCheck(model.Checks.KafkaConsumerLag)
+
+	keys := make([]model.KafkaConsumerGroupKey, 0, len(lags))
+	for key := range lags {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	chart := report.GetOrCreateChart("Consumer lag, messages")
+	for _, key := range keys {
+		lag := lags[key]
+		chart.AddSeries(key.String(), lag)
+		last := lag.Last()
+		lagGrowth := growth(lag, a.w.Ctx.From, a.w.Ctx.To)
+		trend := model.NewTableCell()
+		if lagGrowth > 0 {
+			trend.SetValue("growing")
+		}
+		if last > lagCheck.Threshold && lagGrowth > 0 {
+			lagCheck.AddItem(key.Group)
+			lagCheck.UpdateSeverity(last)
+			trend.UpdateStatus(model.WARNING)
+		}
+		report.
+			GetOrCreateTable("Consumer group", "Topic", "Lag", "Trend").
+			AddRow(
+				model.NewTableCell(key.Group),
+				model.NewTableCell(key.Topic),
+				model.NewTableCell(utils.FormatFloat(last)),
+				trend,
+			)
+	}
+}
+
+func kafkaClusterMetrics(app *model.Application) *model.Kafka {
+	res := model.NewKafka()
+	for _, i := range app.Instances {
+		if i.Kafka == nil {
+			continue
+		}
+		for k, v := range i.Kafka.Brokers {
+			res.Brokers[k] = v
+		}
+		for k, v := range i.Kafka.UnderReplicatedPartitions {
+			res.UnderReplicatedPartitions[k] = v
+		}
+		for k, v := range i.Kafka.OfflinePartitions {
+			res.OfflinePartitions[k] = v
+		}
+		for k, v := range i.Kafka.ConsumerGroupLag {
+			res.ConsumerGroupLag[k] = v
+		}
+	}
+	return res
+}
+
+func kafkaBrokerRegistered(instance *model.Instance, brokers map[string]*timeseries.TimeSeries) bool {
+	for address, ts := range brokers {
+		if ts.Last() != 1 {
+			continue
+		}
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+		if host == instance.Name || strings.HasPrefix(host, instance.Name+".") {
+			return true
+		}
+		for l := range instance.TcpListens {
+			if l.IP == host {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func kafkaConsumerGroupMatches(app *model.Application, group string) bool {
+	if len(app.KafkaConsumerGroups) > 0 {
+		for _, g := range app.KafkaConsumerGroups {
+			if g == group {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.EqualFold(group, app.Id.Name)
+}
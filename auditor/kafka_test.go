@@ -0,0 +1,31 @@
+package auditor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestKafkaConsumerGroupMatches(t *testing.T) {
+	api := model.NewApplication(model.NewApplicationId("default", model.ApplicationKindDeployment, "api"))
+	billing := model.NewApplication(model.NewApplicationId("default", model.ApplicationKindDeployment, "billing"))
+	billing.KafkaConsumerGroups = []string{"invoices", "payments-v2"}
+
+	tests := []struct {
+		app     *model.Application
+		group   string
+		matches bool
+	}{
+		{app: api, group: "api", matches: true},
+		{app: api, group: "API", matches: true},
+		{app: api, group: "rapid-api-consumers"},
+		{app: api, group: "billing"},
+		{app: billing, group: "billing"},
+		{app: billing, group: "invoices", matches: true},
+		{app: billing, group: "payments-v2", matches: true},
+		{app: billing, group: "payments"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.matches, kafkaConsumerGroupMatches(tt.app, tt.group), "%s: %s", tt.app.Id.Name, tt.group)
+	}
+}
@@ -0,0 +1,133 @@
+package auditor
+
+import (
+	"fmt"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/coroot/coroot/utils"
+)
+
+func (a *appAuditor) mongodb() {
+	if !a.app.IsMongodb() {
+		return
+	}
+
+	report := a.addReport(model.AuditReportMongodb)
+	availabilityCheck := report.CreateCheck(model.Checks.MongodbAvailability)
+	replicationCheck := report.CreateCheck(model.Checks.MongodbReplicationLag)
+	connectionsCheck := report.CreateCheck(model.Checks.MongodbConnections)
+	slowOpsCheck := report.CreateCheck(model.Checks.MongodbSlowOperations)
+
+	lags := mongodbReplicationLags(a.app.Instances)
+
+	for _, i := range a.app.Instances {
+		if i.Mongodb == nil {
+			continue
+		}
+		report.
+			GetOrCreateChart("Operations per second").
+			AddSeries(i.Name, i.Mongodb.Ops)
+		report.
+			GetOrCreateChart("Slow operations per second").
+			Column().
+			AddSeries(i.Name, i.Mongodb.SlowOps)
+		maxConnections := timeseries.Sum(i.Mongodb.Connections, i.Mongodb.AvailableConnections)
+		report.
+			GetOrCreateChartInGroup("MongoDB connections <selector>", i.Name).
+			AddSeries("connections", i.Mongodb.Connections).
+			SetThreshold("limit", maxConnections)
+
+		role := i.ClusterRoleLast()
+		lag := lags[i]
+		if lag != nil {
+			report.GetOrCreateChart("Replication lag, seconds").AddSeries(i.Name, lag)
+		}
+
+		if i.IsObsolete() {
+			continue
+		}
+
+		status := model.NewTableCell().SetStatus(model.OK, "up")
+		if !i.Mongodb.IsUp() {
+			availabilityCheck.AddItem(i.Name)
+			status.SetStatus(model.WARNING, "down (no metrics)")
+		}
+
+		roleCell := model.NewTableCell(role.String())
+		switch role {
+		case model.ClusterRolePrimary:
+			roleCell.SetIcon("mdi-database-edit-outline", "rgba(0,0,0,0.87)")
+		case model.ClusterRoleReplica:
+			roleCell.SetIcon("mdi-database-import-outline", "grey")
+		}
+
+		lagCell := model.NewTableCell()
+		if last := lag.Last(); !timeseries.IsNaN(last) {
+			lagCell.SetValue(utils.FormatDuration(timeseries.Duration(last), 1))
+			if last > replicationCheck.Threshold {
+				replicationCheck.AddItem(i.Name)
+				replicationCheck.UpdateSeverity(last)
+			}
+		}
+
+		connectionsCell := model.NewTableCell()
+		if conns, max := i.Mongodb.Connections.Last(), maxConnections.Last(); conns > 0 && max > 0 {
+			connectionsCell.SetValue(fmt.Sprintf("%.0f/%.0f", conns, max))
+			if conns/max*100 > connectionsCheck.Threshold {
+				connectionsCheck.AddItem(i.Name)
+				connectionsCheck.UpdateSeverity(conns / max * 100)
+			}
+		}
+
+		slowOpsCell := model.NewTableCell()
+		if total := i.Mongodb.SlowOps.Reduce(timeseries.NanSum); !timeseries.IsNaN(total) {
+			total *= float32(a.w.Ctx.Step)
+			slowOpsCheck.Inc(int64(total))
+			slowOpsCell.SetValue(fmt.Sprintf("%.0f", total))
+		}
+
+		report.
+			GetOrCreateTable("Instance", "Replica set", "Role", "Status", "Operations", "Slow operations", "Connections", "Replication lag").
+			AddRow(
+				model.NewTableCell(i.Name).AddTag("version: %s", i.Mongodb.Version.Value()),
+				model.NewTableCell(i.Mongodb.ReplicaSet.Value()),
+				roleCell,
+				status,
+				model.NewTableCell(utils.FormatFloat(i.Mongodb.Ops.Last())).SetUnit("/s"),
+				slowOpsCell,
+				connectionsCell,
+				lagCell,
+			)
+	}
+}
+
+func mongodbReplicationLags(instances []*model.Instance) map[*model.Instance]*timeseries.TimeSeries {
+	primaryOptimes := map[string]*timeseries.Aggregate{}
+	for _, i := range instances {
+		if i.Mongodb == nil || i.Mongodb.LastAppliedOptime == nil {
+			continue
+		}
+		rs := i.Mongodb.ReplicaSet.Value()
+		if primaryOptimes[rs] == nil {
+			primaryOptimes[rs] = timeseries.NewAggregate(timeseries.Max)
+		}
+		primaryOptimes[rs].Add(i.Mongodb.LastAppliedOptime)
+	}
+	res := map[*model.Instance]*timeseries.TimeSeries{}
+	for _, i := range instances {
+		if i.Mongodb == nil || i.ClusterRoleLast() != model.ClusterRoleReplica {
+			continue
+		}
+		primaryOptime := primaryOptimes[i.Mongodb.ReplicaSet.Value()]
+		if primaryOptime == nil {
+			continue
+		}
+		res[i] = timeseries.Aggregate2(primaryOptime.Get(), i.Mongodb.LastAppliedOptime, func(primary, applied float32) float32 {
+			if primary < applied {
+				return 0
+			}
+			return primary - applied
+		})
+	}
+	return res
+}
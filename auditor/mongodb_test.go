@@ -0,0 +1,30 @@
+package auditor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMongodbReplicationLags(t *testing.T) {
+	ts := func(v float32) *timeseries.TimeSeries {
+		return timeseries.NewWithData(0, 30, []float32{v})
+	}
+	instance := func(name, rs, role string, optime float32) *model.Instance {
+		i := &model.Instance{Name: name, Mongodb: model.NewMongodb()}
+		i.Mongodb.ReplicaSet.Update(ts(1), rs)
+		i.Mongodb.LastAppliedOptime = ts(optime)
+		i.UpdateClusterRole(role, ts(1))
+		return i
+	}
+	rs0Primary := instance("rs0-0", "rs0", "primary", 1000)
+	rs0Replica := instance("rs0-1", "rs0", "replica", 990)
+	rs1Primary := instance("rs1-0", "rs1", "primary", 500)
+	rs1Replica := instance("rs1-1", "rs1", "replica", 495)
+
+	lags := mongodbReplicationLags([]*model.Instance{rs0Primary, rs0Replica, rs1Primary, rs1Replica, {Name: "exporter"}})
+	assert.Len(t, lags, 2)
+	assert.Equal(t, float32(10), lags[rs0Replica].Last())
+	assert.Equal(t, float32(5), lags[rs1Replica].Last())
+}
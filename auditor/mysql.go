@@ -0,0 +1,117 @@
+package auditor
+
+import (
+	"fmt"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/coroot/coroot/utils"
+)
+
+func (a *appAuditor) mysql() {
+	if !a.app.IsMysql() {
+		return
+	}
+
+	report := a.addReport(model.AuditReportMysql)
+	availabilityCheck := report.CreateCheck(model.Checks.MysqlAvailability)
+	latencyCheck := report.CreateCheck(model.Checks.MysqlLatency)
+	replicationCheck := report.CreateCheck(model.Checks.MysqlReplicationLag)
+	connectionsCheck := report.CreateCheck(model.Checks.MysqlConnections)
+
+	seenLatency := false
+	for _, i := range a.app.Instances {
+		if i.Mysql == nil {
+			continue
+		}
+		latency := i.Mysql.Latency()
+		report.
+			GetOrCreateChart("MySQL query latency, seconds").
+			AddSeries(i.Name, latency)
+		report.
+			GetOrCreateChartInGroup("Queries per second <selector>", "overview").
+			Feature().
+			AddSeries(i.Name, i.Mysql.Queries)
+		report.
+			GetOrCreateChartInGroup("Queries per second <selector>", i.Name).
+			AddSeries("total", i.Mysql.Queries).
+			AddSeries("slow", i.Mysql.SlowQueries, "red")
+		report.
+			GetOrCreateChartInGroup("MySQL connections <selector>", i.Name).
+			AddSeries("connections", i.Mysql.Connections).
+			SetThreshold("max_connections", i.Mysql.MaxConnections)
+		report.
+			GetOrCreateChart("InnoDB row lock waits, per second").
+			AddSeries(i.Name, i.Mysql.RowLockWaits)
+		report.
+			GetOrCreateChart("InnoDB row lock time, seconds/second").
+			AddSeries(i.Name, i.Mysql.RowLockTime)
+		if i.Mysql.IsReplica() {
+			report.
+				GetOrCreateChart("Replication lag, seconds").
+				AddSeries(i.Name, i.Mysql.ReplicationLag)
+		}
+
+		if i.IsObsolete() {
+			continue
+		}
+
+		status := model.NewTableCell().SetStatus(model.OK, "up")
+		if !i.Mysql.IsUp() {
+			availabilityCheck.AddItem(i.Name)
+			status.SetStatus(model.WARNING, "down (no metrics)")
+		}
+
+		roleCell := model.NewTableCell("primary").SetIcon("mdi-database-edit-outline", "rgba(0,0,0,0.87)")
+		lagCell := model.NewTableCell()
+		if i.Mysql.IsReplica() {
+			roleCell = model.NewTableCell("replica").SetIcon("mdi-database-import-outline", "grey")
+			if lag := i.Mysql.ReplicationLag.Last(); !timeseries.IsNaN(lag) {
+				lagCell.SetValue(utils.FormatDuration(timeseries.Duration(lag), 1))
+				if lag > replicationCheck.Threshold {
+					replicationCheck.AddItem(i.Name)
+					replicationCheck.UpdateSeverity(lag)
+				}
+			}
+		}
+
+		switch l := latency.Last(); {
+		case !timeseries.IsNaN(l):
+			seenLatency = true
+			if l > latencyCheck.Threshold {
+				latencyCheck.AddItem(i.Name)
+				latencyCheck.UpdateSeverity(l)
+			}
+		case !i.Mysql.SlowQueries.IsEmpty():
+			// without performance_schema statement metrics, slow queries are the only latency signal
+			seenLatency = true
+			if i.Mysql.SlowQueries.Last() > 0 {
+				latencyCheck.AddItem(i.Name)
+			}
+		}
+
+		connectionsCell := model.NewTableCell()
+		if conns, max := i.Mysql.Connections.Last(), i.Mysql.MaxConnections.Last(); conns > 0 && max > 0 {
+			connectionsCell.SetValue(fmt.Sprintf("%.0f/%.0f", conns, max))
+			if conns/max*100 > connectionsCheck.Threshold {
+				connectionsCheck.AddItem(i.Name)
+				connectionsCheck.UpdateSeverity(conns / max * 100)
+			}
+		}
+
+		report.
+			GetOrCreateTable("Instance", "Role", "Status", "Queries", "Slow queries", "Latency", "Connections", "Replication lag").
+			AddRow(
+				model.NewTableCell(i.Name).AddTag("version: %s", i.Mysql.Version.Value()),
+				roleCell,
+				status,
+				model.NewTableCell(utils.FormatFloat(i.Mysql.Queries.Last())).SetUnit("/s"),
+				model.NewTableCell(utils.FormatFloat(i.Mysql.SlowQueries.Last())).SetUnit("/s"),
+				model.NewTableCell(utils.FormatFloat(latency.Last()*1000)).SetUnit("ms"),
+				connectionsCell,
+				lagCell,
+			)
+	}
+	if !seenLatency {
+		latencyCheck.SetStatus(model.UNKNOWN, "no data")
+	}
+}
@@ -77,6 +77,7 @@ func (a *appAuditor) network() {
 		avg := timeseries.Div(summary.rttSum.Get(), summary.rttCount.Get())
 		if avg.Last() > rttCheck.Threshold {
 			rttCheck.AddItem(appId.Name)
+			rttCheck.UpdateSeverity(avg.Last())
 		}
 		report.GetOrCreateChartInGroup("Network round-trip time to <selector>, seconds", appId.Name).
 			AddSeries("min", summary.rttMin).
@@ -51,6 +51,7 @@ func (a *appAuditor) postgres() {
 			AddSeries(i.Name, i.Postgres.Avg)
 		if i.Postgres.Avg.Last() > latencyCheck.Threshold {
 			latencyCheck.AddItem(i.Name)
+			latencyCheck.UpdateSeverity(i.Postgres.Avg.Last())
 		}
 		report.
 			GetOrCreateChartInGroup("Postgres query latency <selector>, seconds", i.Name).
@@ -167,6 +168,7 @@ func checkReplicationLag(instanceName string, primaryLsn, lag *timeseries.TimeSe
 	}
 	if lagTime > timeseries.Duration(check.Threshold) {
 		check.AddItem(instanceName)
+		check.UpdateSeverity(float32(lagTime))
 	}
 	res.Value, res.Unit = utils.FormatBytes(last)
 	if lagTime > 0 {
@@ -218,6 +220,7 @@ func pgConnections(report *model.AuditReport, instance *model.Instance, connecti
 	if max := instance.Postgres.Settings["max_connections"].Samples.Last(); max > 0 && total > 0 {
 		if total/max*100 > connectionsCheck.Threshold {
 			connectionsCheck.AddItem(instance.Name)
+			connectionsCheck.UpdateSeverity(total / max * 100)
 		}
 	}
 
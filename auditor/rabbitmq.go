@@ -0,0 +1,137 @@
+package auditor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/coroot/coroot/utils"
+	"sort"
+	"strings"
+)
+
+const rabbitmqQueuesLimit = 20
+
+func (a *appAuditor) rabbitmq() {
+	if !a.app.IsRabbitmq() {
+		return
+	}
+
+	report := a.addReport(model.AuditReportRabbitmq)
+	backlogCheck := report.CreateCheck(model.Checks.RabbitmqQueueBacklog)
+
+	queues := map[model.RabbitmqQueueKey]*model.RabbitmqQueue{}
+	for _, i := range a.app.Instances {
+		if i.Rabbitmq == nil {
+			continue
+		}
+		for k, q := range i.Rabbitmq.Queues {
+			queues[k] = q
+		}
+	}
+
+	ready := map[string]model.SeriesData{}
+	unacked := map[string]model.SeriesData{}
+	published := map[string]model.SeriesData{}
+	delivered := map[string]model.SeriesData{}
+	keys := make([]model.RabbitmqQueueKey, 0, len(queues))
+	for k, q := range queues {
+		name := k.String()
+		ready[name] = q.Ready
+		unacked[name] = q.Unacked
+		published[name] = q.PublishRate
+		delivered[name] = q.DeliverRate
+		keys = append(keys, k)
+	}
+	report.GetOrCreateChart("Ready messages").AddMany(ready, 5, timeseries.Max)
+	report.GetOrCreateChart("Unacknowledged messages").AddMany(unacked, 5, timeseries.Max)
+	report.GetOrCreateChart("Published messages, per second").AddMany(published, 5, timeseries.NanSum)
+	report.GetOrCreateChart("Delivered messages, per second").AddMany(delivered, 5, timeseries.NanSum)
+
+	sort.Slice(keys, func(i, j int) bool {
+		ri, rj := queues[keys[i]].Ready.Last(), queues[keys[j]].Ready.Last()
+		if ri == rj || (timeseries.IsNaN(ri) && timeseries.IsNaN(rj)) {
+			return keys[i].String() < keys[j].String()
+		}
+		return ri > rj || timeseries.IsNaN(rj)
+	})
+
+	table := report.
+		CreateTable("Queue", "Ready", "Unacked", "Consumers", "Publish", "Deliver", "Trend").
+		SetSorted(true)
+	backlogged := false
+	for n, k := range keys {
+		q := queues[k]
+		backlog := q.Ready.Last()
+		trend := model.NewTableCell()
+		if growth(q.Ready, a.w.Ctx.From, a.w.Ctx.To) > 0 {
+			trend.SetValue("growing")
+			if backlog > backlogCheck.Threshold {
+				backlogCheck.AddItem(k.String())
+				backlogCheck.UpdateSeverity(backlog)
+				backlogged = true
+				trend.UpdateStatus(model.WARNING)
+			}
+		}
+		if n >= rabbitmqQueuesLimit {
+			continue
+		}
+		table.AddRow(
+			model.NewTableCell(k.String()),
+			model.NewTableCell(utils.FormatFloat(backlog)),
+			model.NewTableCell(utils.FormatFloat(q.Unacked.Last())),
+			model.NewTableCell(utils.FormatFloat(q.Consumers.Last())),
+			model.NewTableCell(utils.FormatFloat(q.PublishRate.Last())).SetUnit("/s"),
+			model.NewTableCell(utils.FormatFloat(q.DeliverRate.Last())).SetUnit("/s"),
+			trend,
+		)
+	}
+
+	if !backlogged {
+		return
+	}
+	consumers := a.rabbitmqConsumers()
+	ids := make([]model.ApplicationId, 0, len(consumers))
+	for id := range consumers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].Name < ids[j].Name
+	})
+	if len(ids) == 0 {
+		return
+	}
+	consumersTable := report.CreateTable("Consumers of the broker", "Consumed messages")
+	for _, id := range ids {
+		consumer := model.NewTableCell(id.Name)
+		consumer.Link = model.NewRouterLink(id.Name).SetRoute("application").SetParam("id", id)
+		consumersTable.AddRow(
+			consumer,
+			model.NewTableCell(utils.FormatFloat(consumers[id].Last())).SetUnit("/s"),
+		)
+	}
+}
+
+func (a *appAuditor) rabbitmqConsumers() map[model.ApplicationId]*timeseries.TimeSeries {
+	res := map[model.ApplicationId]*timeseries.TimeSeries{}
+	for id, connections := range a.app.GetClientsConnections() {
+		consumed := timeseries.NewAggregate(timeseries.NanSum)
+		var consumes bool
+		for _, c := range connections {
+			if c.IsObsolete() {
+				continue
+			}
+			for protocol, byStatus := range c.RequestsCount {
+				if !strings.HasPrefix(string(protocol), "rabbitmq-") || protocol == "rabbitmq-publish" {
+					continue
+				}
+				consumes = true
+				for _, ts := range byStatus {
+					consumed.Add(ts)
+				}
+			}
+		}
+		if consumes {
+			res[id] = consumed.Get()
+		}
+	}
+	return res
+}
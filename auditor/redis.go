@@ -59,6 +59,7 @@ func (a *appAuditor) redis() {
 
 		if avg.Last() > latency.Threshold {
 			latency.AddItem(i.Name)
+			latency.UpdateSeverity(avg.Last())
 		}
 		report.GetOrCreateTable("Instance", "Role", "Status").AddRow(
 			model.NewTableCell(i.Name).AddTag("version: %s", i.Redis.Version.Value()),
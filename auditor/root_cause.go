@@ -0,0 +1,206 @@
+package auditor
+
+import (
+	"fmt"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/utils"
+	"sort"
+	"strings"
+)
+
+const (
+	rootCauseMaxDepth = 3
+	rootCauseMaxHints = 5
+)
+
+func rootCauseHints(w *model.World) {
+	for _, app := range w.Applications {
+		if app.SLOStatus() < model.WARNING {
+			continue
+		}
+		var hints []*model.RootCauseHint
+		hints = append(hints, rolloutHints(w, app)...)
+		hints = append(hints, checkHints(app)...)
+		hints = append(hints, upstreamHints(w, app)...)
+		hints = append(hints, downstreamHints(w, app)...)
+		sort.SliceStable(hints, func(i, j int) bool {
+			return hints[i].Score > hints[j].Score
+		})
+		if len(hints) > rootCauseMaxHints {
+			hints = hints[:rootCauseMaxHints]
+		}
+		app.RootCauseHints = hints
+	}
+}
+
+func rolloutHints(w *model.World, app *model.Application) []*model.RootCauseHint {
+	var res []*model.RootCauseHint
+	for _, d := range app.Deployments {
+		if d.StartedAt.Before(w.Ctx.From) {
+			continue
+		}
+		res = append(res, &model.RootCauseHint{
+			Type:          model.RootCauseHintTypeRollout,
+			ApplicationId: app.Id,
+			Title:         fmt.Sprintf("rollout of %s", d.Version()),
+			Explanation: fmt.Sprintf(
+				"%s was rolled out %s before the end of the period, changes in the new version may have affected the SLOs",
+				app.Id.Name, utils.FormatDuration(w.Ctx.To.Sub(d.StartedAt), 1),
+			),
+			Score: 0.9,
+		})
+	}
+	return res
+}
+
+func checkHints(app *model.Application) []*model.RootCauseHint {
+	var res []*model.RootCauseHint
+	for _, r := range app.Reports {
+		if r.Name == model.AuditReportSLO {
+			continue
+		}
+		for _, ch := range r.Checks {
+			if ch.Status < model.WARNING {
+				continue
+			}
+			h := &model.RootCauseHint{
+				Type:          model.RootCauseHintTypeCheck,
+				ApplicationId: app.Id,
+				Title:         fmt.Sprintf("%s / %s", r.Name, ch.Title),
+				Explanation:   fmt.Sprintf("the %s check of %s is failing: %s", ch.Title, app.Id.Name, ch.Message),
+				Score:         statusScore(ch.Status) * 0.8,
+			}
+			if ch.Id == model.Checks.CPUNode.Id {
+				h.Type = model.RootCauseHintTypeNode
+				h.Title = "high CPU usage on " + strings.Join(appNodes(app), ", ")
+				h.Explanation = fmt.Sprintf("%s is running on nodes with CPU saturation: %s", app.Id.Name, ch.Message)
+			}
+			res = append(res, h)
+		}
+	}
+	return res
+}
+
+func upstreamHints(w *model.World, app *model.Application) []*model.RootCauseHint {
+	var res []*model.RootCauseHint
+	visited := map[model.ApplicationId]bool{app.Id: true}
+	type step struct {
+		app  *model.Application
+		path []string
+	}
+	queue := []step{{app: app, path: []string{app.Id.Name}}}
+	for depth := 1; depth <= rootCauseMaxDepth && len(queue) > 0; depth++ {
+		var next []step
+		for _, s := range queue {
+			for _, upstream := range upstreams(w, s.app) {
+				if visited[upstream.Id] {
+					continue
+				}
+				visited[upstream.Id] = true
+				path := append(append([]string{}, s.path...), upstream.Id.Name)
+				next = append(next, step{app: upstream, path: path})
+				failed, status := failedChecks(upstream)
+				if len(failed) == 0 {
+					continue
+				}
+				res = append(res, &model.RootCauseHint{
+					Type:          model.RootCauseHintTypeUpstream,
+					ApplicationId: upstream.Id,
+					Title:         fmt.Sprintf("%s is %s", upstream.Id.Name, status.String()),
+					Explanation: fmt.Sprintf(
+						"%s depends on %s (%s), which has failed checks: %s",
+						app.Id.Name, upstream.Id.Name, strings.Join(path, " → "), strings.Join(failed, "; "),
+					),
+					Score: statusScore(status) / float32(depth),
+				})
+			}
+		}
+		queue = next
+	}
+	return res
+}
+
+func downstreamHints(w *model.World, app *model.Application) []*model.RootCauseHint {
+	var res []*model.RootCauseHint
+	seen := map[model.ApplicationId]bool{}
+	for _, c := range app.Downstreams {
+		if c.Instance == nil || c.Instance.OwnerId == app.Id || seen[c.Instance.OwnerId] {
+			continue
+		}
+		seen[c.Instance.OwnerId] = true
+		client := w.GetApplication(c.Instance.OwnerId)
+		if client == nil {
+			continue
+		}
+		for _, d := range client.Deployments {
+			if d.StartedAt.Before(w.Ctx.From) {
+				continue
+			}
+			res = append(res, &model.RootCauseHint{
+				Type:          model.RootCauseHintTypeDownstream,
+				ApplicationId: client.Id,
+				Title:         fmt.Sprintf("rollout of %s %s", client.Id.Name, d.Version()),
+				Explanation: fmt.Sprintf(
+					"%s is a client of %s and was rolled out %s before the end of the period, the new version may have changed the load",
+					client.Id.Name, app.Id.Name, utils.FormatDuration(w.Ctx.To.Sub(d.StartedAt), 1),
+				),
+				Score: 0.4,
+			})
+		}
+	}
+	return res
+}
+
+func upstreams(w *model.World, app *model.Application) []*model.Application {
+	var res []*model.Application
+	seen := map[model.ApplicationId]bool{}
+	for _, i := range app.Instances {
+		for _, u := range i.Upstreams {
+			if u.RemoteInstance == nil || u.RemoteInstance.OwnerId == app.Id || seen[u.RemoteInstance.OwnerId] {
+				continue
+			}
+			seen[u.RemoteInstance.OwnerId] = true
+			if upstream := w.GetApplication(u.RemoteInstance.OwnerId); upstream != nil {
+				res = append(res, upstream)
+			}
+		}
+	}
+	return res
+}
+
+func failedChecks(app *model.Application) ([]string, model.Status) {
+	var res []string
+	status := model.OK
+	for _, r := range app.Reports {
+		for _, ch := range r.Checks {
+			if ch.Status < model.WARNING {
+				continue
+			}
+			res = append(res, fmt.Sprintf("%s / %s: %s", r.Name, ch.Title, ch.Message))
+			if ch.Status > status {
+				status = ch.Status
+			}
+		}
+	}
+	return res, status
+}
+
+func appNodes(app *model.Application) []string {
+	nodes := utils.NewStringSet()
+	for _, i := range app.Instances {
+		if i.Node != nil {
+			nodes.Add(i.Node.Name.Value())
+		}
+	}
+	return nodes.Items()
+}
+
+func statusScore(s model.Status) float32 {
+	switch s {
+	case model.CRITICAL:
+		return 1
+	case model.WARNING:
+		return 0.7
+	}
+	return 0
+}
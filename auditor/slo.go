@@ -6,24 +6,37 @@ import (
 	"github.com/coroot/coroot/timeseries"
 	"github.com/coroot/coroot/utils"
 	"github.com/dustin/go-humanize"
+	"sort"
+	"strings"
 )
 
 func (a *appAuditor) slo() {
 	report := a.addReport(model.AuditReportSLO)
 	requestsChart(a.app, report)
-	availability(a.w.Ctx, a.app, report)
-	latency(a.w.Ctx, a.app, report)
+	rules := a.w.CheckConfigs.GetAlertRules(a.app.Id)
+	availability(a.w.Ctx, a.app, report, rules)
+	latency(a.w.Ctx, a.w.CheckConfigs, a.app, report, rules)
+	dependencies(a.w.Ctx, a.app, report, rules)
 	clientRequests(a.app, report)
+	routes(a.app, report)
 }
 
-func availability(ctx timeseries.Context, app *model.Application, report *model.AuditReport) {
-	check := report.CreateCheck(model.Checks.SLOAvailability)
+func availability(ctx timeseries.Context, app *model.Application, report *model.AuditReport, rules []model.AlertRule) {
 	if len(app.AvailabilitySLIs) == 0 {
+		check := report.CreateCheck(model.Checks.SLOAvailability)
 		check.SetStatus(model.UNKNOWN, "not configured")
 		return
 	}
-	sli := app.AvailabilitySLIs[0]
+	for i, sli := range app.AvailabilitySLIs {
+		check := report.CreateCheck(model.Checks.SLOAvailability)
+		title := sloTitle(model.Checks.SLOAvailability.Title, sli.Config.Name, i)
+		check.Title = title
+		check.Threshold = sli.Config.ObjectivePercentage
+		availabilitySLI(ctx, sli, report, check, title, rules)
+	}
+}
 
+func availabilitySLI(ctx timeseries.Context, sli *model.AvailabilitySLI, report *model.AuditReport, check *model.Check, title string, rules []model.AlertRule) {
 	if !sli.TotalRequests.IsEmpty() {
 		failed := sli.FailedRequests
 		if failed.IsEmpty() {
@@ -36,7 +49,7 @@ func availability(ctx timeseries.Context, app *model.Application, report *model.
 			},
 		)
 		chart := report.
-			GetOrCreateChart("Availability").
+			GetOrCreateChart(title).
 			AddSeries("successful requests", successfulPercentage)
 		chart.Threshold = &model.Series{
 			Name:  "target",
@@ -61,19 +74,35 @@ func availability(ctx timeseries.Context, app *model.Application, report *model.
 	} else {
 		failedRaw = failedRaw.Map(timeseries.NanToZero)
 	}
-	if br := model.CheckBurnRates(ctx.To, failedRaw, sli.TotalRequestsRaw, sli.Config.ObjectivePercentage); br.Severity > model.UNKNOWN {
+	if br := model.CheckBurnRates(ctx.To, failedRaw, sli.TotalRequestsRaw, sli.Config.ObjectivePercentage, rules); br.Severity > model.UNKNOWN {
 		check.SetStatus(br.Severity, br.FormatSLOStatus())
 	}
 }
 
-func latency(ctx timeseries.Context, app *model.Application, report *model.AuditReport) {
-	check := report.CreateCheck(model.Checks.SLOLatency)
+func latency(ctx timeseries.Context, checkConfigs model.CheckConfigs, app *model.Application, report *model.AuditReport, rules []model.AlertRule) {
 	if len(app.LatencySLIs) == 0 {
+		cfg, _ := checkConfigs.GetLatency(app.Id, app.Category)
+		check := report.CreateCheck(model.Checks.SLOLatency)
+		check.Threshold = cfg.ObjectivePercentage
+		check.ConditionFormatTemplate = latencyConditionFormat(cfg.ObjectiveBucket)
 		check.SetStatus(model.UNKNOWN, "not configured")
 		return
 	}
-	sli := app.LatencySLIs[0]
+	for i, sli := range app.LatencySLIs {
+		check := report.CreateCheck(model.Checks.SLOLatency)
+		title := sloTitle(model.Checks.SLOLatency.Title, sli.Config.Name, i)
+		check.Title = title
+		check.Threshold = sli.Config.ObjectivePercentage
+		check.ConditionFormatTemplate = latencyConditionFormat(sli.Config.ObjectiveBucket)
+		latencySLI(ctx, sli, report, check, title, rules)
+	}
+}
+
+func latencyConditionFormat(bucket float32) string {
+	return strings.Replace(model.Checks.SLOLatency.ConditionFormatTemplate, "<bucket>", utils.FormatLatency(bucket), 1)
+}
 
+func latencySLI(ctx timeseries.Context, sli *model.LatencySLI, report *model.AuditReport, check *model.Check, title string, rules []model.AlertRule) {
 	total, fast := sli.GetTotalAndFast(false)
 	if !total.IsEmpty() {
 		fastPercentage := timeseries.Aggregate2(
@@ -83,7 +112,7 @@ func latency(ctx timeseries.Context, app *model.Application, report *model.Audit
 			},
 		)
 		chart := report.
-			GetOrCreateChart("Latency").
+			GetOrCreateChart(title).
 			AddSeries("requests served faster than "+utils.FormatLatency(sli.Config.ObjectiveBucket), fastPercentage)
 		chart.Threshold = &model.Series{
 			Name:  "target",
@@ -109,11 +138,41 @@ func latency(ctx timeseries.Context, app *model.Application, report *model.Audit
 		fastRaw = fastRaw.Map(timeseries.NanToZero)
 	}
 	slowRaw := timeseries.Sub(totalRaw, fastRaw)
-	if br := model.CheckBurnRates(ctx.To, slowRaw, totalRaw, sli.Config.ObjectivePercentage); br.Severity > model.UNKNOWN {
+	if br := model.CheckBurnRates(ctx.To, slowRaw, totalRaw, sli.Config.ObjectivePercentage, rules); br.Severity > model.UNKNOWN {
 		check.SetStatus(br.Severity, br.FormatSLOStatus())
 	}
 }
 
+func dependencies(ctx timeseries.Context, app *model.Application, report *model.AuditReport, rules []model.AlertRule) {
+	for _, sli := range app.DependencySLIs {
+		check := report.CreateCheck(model.Checks.SLODependency)
+		title := "Availability of " + sli.Upstream.Name
+		check.Title = title
+		check.Threshold = sli.Availability.Config.ObjectivePercentage
+		availabilitySLI(ctx, sli.Availability, report, check, title, rules)
+
+		if sli.Latency != nil {
+			check = report.CreateCheck(model.Checks.SLODependency)
+			title = "Latency of " + sli.Upstream.Name
+			check.Title = title
+			check.Threshold = sli.Latency.Config.ObjectivePercentage
+			check.ConditionFormatTemplate = "the percentage of requests to the dependency served faster than " +
+				utils.FormatLatency(sli.Latency.Config.ObjectiveBucket) + " < <threshold>"
+			latencySLI(ctx, sli.Latency, report, check, title, rules)
+		}
+	}
+}
+
+func sloTitle(title, name string, i int) string {
+	switch {
+	case name != "":
+		return title + ": " + name
+	case i > 0:
+		return fmt.Sprintf("%s #%d", title, i+1)
+	}
+	return title
+}
+
 func requestsChart(app *model.Application, report *model.AuditReport) {
 	ch := report.GetOrCreateChart(fmt.Sprintf("Requests to the <var>%s</var> app, per second", app.Id.Name)).Sorted().Stacked()
 	if len(app.LatencySLIs) > 0 {
@@ -209,3 +268,83 @@ func clientRequests(app *model.Application, report *model.AuditReport) {
 		t.AddRow(client, chart, requests, latency, errors)
 	}
 }
+
+const maxRoutes = 10
+
+type routeSummary struct {
+	sli    *model.RouteSLI
+	rps    float32
+	errors float32
+	slow   float32
+}
+
+func routes(app *model.Application, report *model.AuditReport) {
+	if len(app.RouteSLIs) == 0 {
+		return
+	}
+	objectiveBucket := float32(0)
+	if len(app.LatencySLIs) > 0 {
+		objectiveBucket = app.LatencySLIs[0].Config.ObjectiveBucket
+	}
+
+	var summaries []*routeSummary
+	for _, sli := range app.RouteSLIs {
+		s := &routeSummary{sli: sli, rps: sli.TotalRequests.Last(), errors: sli.FailedRequests.Last()}
+		if timeseries.IsNaN(s.rps) || s.rps <= 0 {
+			continue
+		}
+		if timeseries.IsNaN(s.errors) {
+			s.errors = 0
+		}
+		total, fast := float32(0), timeseries.NaN
+		for _, b := range sli.Histogram {
+			if objectiveBucket > 0 && b.Le == objectiveBucket {
+				fast = b.TimeSeries.Last()
+			}
+			if timeseries.IsInf(b.Le, 1) {
+				total = b.TimeSeries.Last()
+			}
+		}
+		if total > 0 && !timeseries.IsNaN(fast) {
+			s.slow = (total - fast) / total
+		}
+		summaries = append(summaries, s)
+	}
+	if len(summaries) == 0 {
+		return
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		si, sj := summaries[i], summaries[j]
+		if si.errors != sj.errors {
+			return si.errors > sj.errors
+		}
+		if si.slow != sj.slow {
+			return si.slow > sj.slow
+		}
+		return si.rps > sj.rps
+	})
+	if len(summaries) > maxRoutes {
+		summaries = summaries[:maxRoutes]
+	}
+
+	slowHeader := "Slow"
+	if objectiveBucket > 0 {
+		slowHeader = "Slower than " + utils.FormatLatency(objectiveBucket)
+	}
+	t := report.CreateTable("Route", "", "Requests", "Errors", slowHeader).SetSorted(true)
+	for _, s := range summaries {
+		route := model.NewTableCell(s.sli.Route)
+		chart := model.NewTableCell().SetChart(s.sli.TotalRequests)
+		requests := model.NewTableCell(utils.FormatFloat(s.rps)).SetUnit("/s")
+		errors := model.NewTableCell().SetUnit("/s")
+		if s.errors > 0 {
+			errors.SetValue(utils.FormatFloat(s.errors))
+			errors.AddTag("%.0f%%", s.errors*100/s.rps)
+		}
+		slow := model.NewTableCell()
+		if s.slow > 0 {
+			slow.SetValue(utils.FormatPercentage(s.slow * 100))
+		}
+		t.AddRow(route, chart, requests, errors, slow)
+	}
+}
@@ -0,0 +1,48 @@
+package auditor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestRoutesSlow(t *testing.T) {
+	ts := func(v float32) *timeseries.TimeSeries {
+		return timeseries.NewWithData(0, 30, []float32{v})
+	}
+	slowColumn := func(app *model.Application) (string, string) {
+		report := model.NewAuditReport(app, timeseries.Context{From: 0, To: 30, Step: 30}, nil, model.AuditReportSLO)
+		routes(app, report)
+		require.Len(t, report.Widgets, 1)
+		table := report.Widgets[0].Table
+		require.Len(t, table.Rows, 1)
+		return table.Header[4], table.Rows[0].Cells[4].Value
+	}
+	app := model.NewApplication(model.NewApplicationId("default", model.ApplicationKindDeployment, "api"))
+	app.RouteSLIs = []*model.RouteSLI{{
+		Route:         "/orders",
+		TotalRequests: ts(10),
+		Histogram: []model.HistogramBucket{
+			{Le: 0.1, TimeSeries: ts(6)},
+			{Le: 0.5, TimeSeries: ts(9)},
+			{Le: float32(math.Inf(1)), TimeSeries: ts(10)},
+		},
+	}}
+
+	header, value := slowColumn(app)
+	assert.Equal(t, "Slow", header)
+	assert.Equal(t, "", value)
+
+	app.LatencySLIs = []*model.LatencySLI{{Config: model.CheckConfigSLOLatency{ObjectiveBucket: 0.5}}}
+	header, value = slowColumn(app)
+	assert.Equal(t, "Slower than 500 ms", header)
+	assert.Equal(t, "10%", value)
+
+	app.LatencySLIs[0].Config.ObjectiveBucket = 0.25
+	header, value = slowColumn(app)
+	assert.Equal(t, "Slower than 250 ms", header)
+	assert.Equal(t, "", value)
+}
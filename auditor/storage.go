@@ -25,8 +25,9 @@ func (a *appAuditor) storage() {
 					report.GetOrCreateChartInGroup("I/O utilization <selector>, %", v.MountPoint).
 						AddSeries(i.Name, d.IOUtilizationPercent)
 
-					if d.IOUtilizationPercent.Last() > ioCheck.Threshold {
+					if ioCheck.Exceeds(d.IOUtilizationPercent, ioCheck.Threshold) {
 						ioCheck.AddItem("%s:%s", i.Name, v.MountPoint)
+						ioCheck.UpdateSeverity(d.IOUtilizationPercent.Last())
 					}
 
 					report.GetOrCreateChartInGroup("IOPS <selector>", fullName).
@@ -57,8 +58,19 @@ func (a *appAuditor) storage() {
 							humanize.Bytes(uint64(usage)),
 							humanize.Bytes(uint64(capacity))),
 						)
-						if percentage > spaceCheck.Threshold {
+						usagePercentage := timeseries.Div(v.UsedBytes, v.CapacityBytes).Map(func(t timeseries.Time, x float32) float32 {
+							return x * 100
+						})
+						if spaceCheck.Exceeds(usagePercentage, spaceCheck.Threshold) {
 							spaceCheck.AddItem("%s:%s", i.Name, v.MountPoint)
+							spaceCheck.UpdateSeverity(percentage)
+						}
+						if ttf := timeToFull(v.UsedBytes, capacity, a.w.Ctx.To); ttf > 0 {
+							space.AddTag("full in ~%s", utils.FormatDuration(ttf, 1))
+							if spaceCheck.Horizon > 0 && ttf < spaceCheck.Horizon {
+								spaceCheck.AddItem("%s:%s", i.Name, v.MountPoint)
+								spaceCheck.UpdateSeverity(percentage)
+							}
 						}
 					}
 					report.GetOrCreateTable("Volume", "Latency", "I/O", "Space", "Device").AddRow(
@@ -81,3 +93,19 @@ func (a *appAuditor) storage() {
 		spaceCheck.SetStatus(model.UNKNOWN, "no volumes found")
 	}
 }
+
+func timeToFull(used *timeseries.TimeSeries, capacity float32, now timeseries.Time) timeseries.Duration {
+	lr := timeseries.NewLinearRegression(used)
+	if lr == nil {
+		return 0
+	}
+	growthPerHour := lr.Calc(now) - lr.Calc(now.Add(-timeseries.Hour))
+	if timeseries.IsNaN(growthPerHour) || growthPerHour <= 0 {
+		return 0
+	}
+	free := capacity - used.Last()
+	if free <= 0 {
+		return 0
+	}
+	return timeseries.Duration(free / growthPerHour * float32(timeseries.Hour))
+}
@@ -70,3 +70,15 @@ func cpuByModeChart(ch *model.Chart, modes map[string]*timeseries.TimeSeries) {
 		ch.AddSeries(mode, v, color)
 	}
 }
+
+func growth(ts *timeseries.TimeSeries, from, to timeseries.Time) float32 {
+	lr := timeseries.NewLinearRegression(ts)
+	if lr == nil {
+		return 0
+	}
+	res := lr.Calc(to) - lr.Calc(from)
+	if timeseries.IsNaN(res) {
+		return 0
+	}
+	return res
+}
@@ -81,16 +81,22 @@ func (c *Cache) updaterWorker(projects *sync.Map, projectId db.ProjectId) {
 			queries = append(queries, q)
 		}
 		for appId := range checkConfigs {
-			availabilityCfg, _ := checkConfigs.GetAvailability(appId)
-			if availabilityCfg.Custom {
-				queries = append(queries, availabilityCfg.Total(), availabilityCfg.Failed())
+			availabilityCfgs, _ := checkConfigs.GetAvailabilityAll(appId)
+			for _, cfg := range availabilityCfgs {
+				if cfg.Custom {
+					queries = append(queries, cfg.Total(), cfg.Failed())
+				}
 			}
-			latencyCfg, _ := checkConfigs.GetLatency(appId, model.CalcApplicationCategory(appId, project.Settings.ApplicationCategories))
-			if latencyCfg.Custom {
-				queries = append(queries, latencyCfg.Histogram())
+			latencyCfgs, _ := checkConfigs.GetLatencyAll(appId, model.CalcApplicationCategory(appId, project.Settings.ApplicationCategories))
+			for _, cfg := range latencyCfgs {
+				if cfg.Custom {
+					queries = append(queries, cfg.Histogram())
+				}
 			}
 		}
 
+		queries = append(queries, c.customCheckQueries(project)...)
+
 		var recordingRules []string
 		for q := range constructor.RecordingRules {
 			recordingRules = append(recordingRules, q)
@@ -265,6 +271,62 @@ func (c *Cache) processRecordingRules(now timeseries.Time, project *db.Project,
 	}
 }
 
+func (c *Cache) customCheckQueries(project *db.Project) []string {
+	if len(project.Settings.CustomChecks) == 0 {
+		return nil
+	}
+	var apps []model.ApplicationId
+	for i := range project.Settings.CustomChecks {
+		if project.Settings.CustomChecks[i].ApplicationId.IsZero() {
+			apps = c.getApplications(project)
+			break
+		}
+	}
+
+	var res []string
+	for i := range project.Settings.CustomChecks {
+		check := &project.Settings.CustomChecks[i]
+		ids := apps
+		if !check.ApplicationId.IsZero() {
+			ids = []model.ApplicationId{check.ApplicationId}
+		}
+		for _, appId := range ids {
+			if !check.Matches(appId, model.CalcApplicationCategory(appId, project.Settings.ApplicationCategories)) {
+				continue
+			}
+			q, err := check.RenderQuery(appId)
+			if err != nil {
+				klog.Warningln("failed to render custom check query:", err)
+				continue
+			}
+			res = append(res, q)
+		}
+	}
+	return res
+}
+
+func (c *Cache) getApplications(project *db.Project) []model.ApplicationId {
+	cacheClient := c.GetCacheClient(project)
+	to, err := cacheClient.GetTo()
+	if err != nil {
+		klog.Errorln(err)
+		return nil
+	}
+	if to.IsZero() {
+		return nil
+	}
+	step := project.Prometheus.RefreshInterval
+	to = to.Truncate(step)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	res, err := constructor.New(c.db, project, cacheClient).LoadApplicationIds(ctx, to.Add(-timeseries.Hour), to, step)
+	if err != nil {
+		klog.Errorln("failed to load applications:", err)
+		return nil
+	}
+	return res
+}
+
 type recordingRulesProcessor struct {
 	db          *db.DB
 	project     *db.Project
@@ -280,7 +342,7 @@ func (p *recordingRulesProcessor) QueryRange(ctx context.Context, query string,
 	if p.cacheTo.Before(to) {
 		return nil, fmt.Errorf("cache is outdated")
 	}
-	c := constructor.New(p.db, p.project, p.cacheClient, constructor.OptionLoadPerConnectionHistograms, constructor.OptionDoNotLoadRawSLIs)
+	c := constructor.New(p.db, p.project, p.cacheClient, constructor.OptionLoadPerConnectionHistograms, constructor.OptionDoNotLoadRawSLIs, constructor.OptionDoNotLoadCustomChecks)
 	world, err := c.LoadWorld(ctx, from, to, step, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load world: %w", err)
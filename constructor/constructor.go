@@ -24,6 +24,7 @@ type Option int
 const (
 	OptionLoadPerConnectionHistograms Option = iota
 	OptionDoNotLoadRawSLIs
+	OptionDoNotLoadCustomChecks
 )
 
 type Constructor struct {
@@ -105,13 +106,71 @@ func (c *Constructor) LoadWorld(ctx context.Context, from, to timeseries.Time, s
 	prof.stage("join_db_cluster", func() { joinDBClusterComponents(w) })
 	prof.stage("calc_app_categories", func() { c.calcApplicationCategories(w) })
 	prof.stage("load_sli", func() { c.loadSLIs(w, metrics) })
+	prof.stage("load_custom_checks", func() { c.loadCustomChecks(ctx, w, from, to, step) })
 	prof.stage("load_app_deployments", func() { c.loadApplicationDeployments(w) })
+	prof.stage("load_app_settings", func() { c.loadApplicationSettings(w) })
 	prof.stage("calc_app_events", func() { calcAppEvents(w) })
 
 	klog.Infof("got %d nodes, %d services, %d applications", len(w.Nodes), len(w.Services), len(w.Applications))
 	return w, nil
 }
 
+// LoadSLIs builds a world containing only the applications that have SLIs and their SLIs.
+// Unlike LoadWorld, it only queries the SLI recording rules, so it's suitable for long time ranges.
+func (c *Constructor) LoadApplicationIds(ctx context.Context, from, to timeseries.Time, step timeseries.Duration) ([]model.ApplicationId, error) {
+	metrics, err := c.prom.QueryRange(ctx, qRecordingRuleApplicationInfo, from, to, step)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]model.ApplicationId, 0, len(metrics))
+	for _, m := range metrics {
+		if id, err := model.NewApplicationIdFromString(m.Labels["application"]); err == nil {
+			res = append(res, id)
+		}
+	}
+	return res, nil
+}
+
+func (c *Constructor) LoadSLIs(ctx context.Context, from, to timeseries.Time, step timeseries.Duration) (*model.World, error) {
+	w := model.NewWorld(from, to, step)
+	var err error
+	w.CheckConfigs, err = c.db.GetCheckConfigs(c.project.Id)
+	if err != nil {
+		return nil, err
+	}
+	queries := map[string]cacheQuery{}
+	addQuery := func(name, statsName, query string, sli bool) {
+		queries[name] = cacheQuery{query: query, from: from, to: to, step: step, statsName: statsName}
+	}
+	addQuery(qRecordingRuleInboundRequestsTotal, qRecordingRuleInboundRequestsTotal, qRecordingRuleInboundRequestsTotal, true)
+	addQuery(qRecordingRuleInboundRequestsHistogram, qRecordingRuleInboundRequestsHistogram, qRecordingRuleInboundRequestsHistogram, true)
+	c.addCustomSLIQueries(w.CheckConfigs, addQuery)
+	metrics, err := c.runQueries(ctx, queries, nil)
+	if err != nil {
+		if !errors.Is(err, ErrUnknownQuery) {
+			return nil, err
+		}
+		klog.Warningln(err)
+	}
+	for _, name := range []string{qRecordingRuleInboundRequestsTotal, qRecordingRuleInboundRequestsHistogram} {
+		for _, m := range metrics[name] {
+			if id, err := model.NewApplicationIdFromString(m.Labels["application"]); err == nil {
+				w.GetOrCreateApplication(id)
+			}
+		}
+	}
+	for name := range metrics {
+		if parts := strings.Split(name, "/"); len(parts) == 4 && parts[0] == qApplicationCustomSLI {
+			if id, err := model.NewApplicationIdFromString(parts[1]); err == nil && id.ConfigLevel() == model.CheckConfigLevelApplication {
+				w.GetOrCreateApplication(id)
+			}
+		}
+	}
+	c.calcApplicationCategories(w)
+	c.loadSLIs(w, metrics)
+	return w, nil
+}
+
 type cacheQuery struct {
 	query     string
 	from, to  timeseries.Time
@@ -121,7 +180,7 @@ type cacheQuery struct {
 
 func (c *Constructor) queryCache(ctx context.Context, from, to timeseries.Time, step timeseries.Duration, checkConfigs model.CheckConfigs, stats map[string]QueryStats) (map[string][]model.MetricValues, error) {
 	queries := map[string]cacheQuery{}
-	rawFrom := to.Add(-model.MaxAlertRuleWindow)
+	rawFrom := to.Add(-checkConfigs.MaxAlertRuleWindow())
 	rawStep := c.project.Prometheus.RefreshInterval
 
 	addQuery := func(name, statsName, query string, sli bool) {
@@ -139,22 +198,35 @@ func (c *Constructor) queryCache(ctx context.Context, from, to timeseries.Time,
 	}
 
 	for name := range RecordingRules {
-		addQuery(name, name, name, true)
+		addQuery(name, name, name, !recordingRulesWithoutRaw[name])
 	}
 
+	c.addCustomSLIQueries(checkConfigs, addQuery)
+
+	return c.runQueries(ctx, queries, stats)
+}
+
+func (c *Constructor) addCustomSLIQueries(checkConfigs model.CheckConfigs, addQuery func(name, statsName, query string, sli bool)) {
 	for appId := range checkConfigs {
-		qName := fmt.Sprintf("%s/%s/", qApplicationCustomSLI, appId)
-		availabilityCfg, _ := checkConfigs.GetAvailability(appId)
-		if availabilityCfg.Custom {
-			addQuery(qName+"total_requests", qApplicationCustomSLI, availabilityCfg.Total(), true)
-			addQuery(qName+"failed_requests", qApplicationCustomSLI, availabilityCfg.Failed(), true)
+		availabilityCfgs, _ := checkConfigs.GetAvailabilityAll(appId)
+		for i, cfg := range availabilityCfgs {
+			if cfg.Custom {
+				qName := fmt.Sprintf("%s/%s/%d/", qApplicationCustomSLI, appId, i)
+				addQuery(qName+"total_requests", qApplicationCustomSLI, cfg.Total(), true)
+				addQuery(qName+"failed_requests", qApplicationCustomSLI, cfg.Failed(), true)
+			}
 		}
-		latencyCfg, _ := checkConfigs.GetLatency(appId, model.CalcApplicationCategory(appId, c.project.Settings.ApplicationCategories))
-		if latencyCfg.Custom {
-			addQuery(qName+"requests_histogram", qApplicationCustomSLI, latencyCfg.Histogram(), true)
+		latencyCfgs, _ := checkConfigs.GetLatencyAll(appId, model.CalcApplicationCategory(appId, c.project.Settings.ApplicationCategories))
+		for i, cfg := range latencyCfgs {
+			if cfg.Custom {
+				qName := fmt.Sprintf("%s/%s/%d/", qApplicationCustomSLI, appId, i)
+				addQuery(qName+"requests_histogram", qApplicationCustomSLI, cfg.Histogram(), true)
+			}
 		}
 	}
+}
 
+func (c *Constructor) runQueries(ctx context.Context, queries map[string]cacheQuery, stats map[string]QueryStats) (map[string][]model.MetricValues, error) {
 	res := make(map[string][]model.MetricValues, len(queries))
 	var lock sync.Mutex
 	var lastErr error
@@ -209,6 +281,23 @@ func (c *Constructor) loadApplicationDeployments(w *model.World) {
 	}
 }
 
+func (c *Constructor) loadApplicationSettings(w *model.World) {
+	byApp, err := c.db.GetApplicationsSettings(c.project.Id)
+	if err != nil {
+		klog.Errorln(err)
+		return
+	}
+	for id, settings := range byApp {
+		app := w.GetApplication(id)
+		if app == nil {
+			continue
+		}
+		if settings.Kafka != nil {
+			app.KafkaConsumerGroups = settings.Kafka.ConsumerGroups
+		}
+	}
+}
+
 type promJob struct {
 	job      string
 	instance string
@@ -253,6 +342,21 @@ func enrichInstances(w *model.World, metrics map[string][]model.MetricValues, rd
 			case strings.HasPrefix(queryName, "redis_"):
 				instance := findInstance(instancesByPod, instancesByListen, rdsInstancesById, m.Labels, model.ApplicationTypeRedis, model.ApplicationTypeKeyDB)
 				redis(instance, queryName, m)
+			case strings.HasPrefix(queryName, "mysql_"):
+				instance := findInstance(instancesByPod, instancesByListen, rdsInstancesById, m.Labels, model.ApplicationTypeMysql)
+				mysql(instance, queryName, m)
+			case strings.HasPrefix(queryName, "mongodb_"):
+				instance := findInstance(instancesByPod, instancesByListen, rdsInstancesById, m.Labels, model.ApplicationTypeMongodb)
+				mongodb(instance, queryName, m)
+			case strings.HasPrefix(queryName, "kafka_"):
+				instance := findInstance(instancesByPod, instancesByListen, rdsInstancesById, m.Labels, model.ApplicationTypeKafka)
+				kafka(instance, queryName, m)
+			case strings.HasPrefix(queryName, "elasticsearch_"):
+				instance := findInstance(instancesByPod, instancesByListen, rdsInstancesById, elasticsearchNodeLabels(m.Labels), model.ApplicationTypeElasticsearch)
+				elasticsearch(instance, queryName, m)
+			case strings.HasPrefix(queryName, "rabbitmq_"):
+				instance := findInstance(instancesByPod, instancesByListen, rdsInstancesById, m.Labels, model.ApplicationTypeRabbitmq)
+				rabbitmq(instance, queryName, m)
 			}
 		}
 	}
@@ -109,6 +109,12 @@ func loadContainers(w *model.World, metrics map[string][]model.MetricValues, pjs
 						c.RequestsCount[protocol] = map[string]*timeseries.TimeSeries{}
 					}
 					c.RequestsCount[protocol][status] = merge(c.RequestsCount[protocol][status], m.Values, timeseries.NanSum)
+					if route := m.Labels["route"]; protocol == "http" && route != "" { // raw paths are not used to keep the cardinality bounded
+						if c.RequestsCountByRoute[route] == nil {
+							c.RequestsCountByRoute[route] = map[string]*timeseries.TimeSeries{}
+						}
+						c.RequestsCountByRoute[route][status] = merge(c.RequestsCountByRoute[route][status], m.Values, timeseries.NanSum)
+					}
 				}
 			case "container_http_requests_latency", "container_postgres_queries_latency", "container_redis_queries_latency",
 				"container_memcached_queries_latency", "container_mysql_queries_latency", "container_mongo_queries_latency",
@@ -131,6 +137,12 @@ func loadContainers(w *model.World, metrics map[string][]model.MetricValues, pjs
 						c.RequestsHistogram[protocol] = map[float32]*timeseries.TimeSeries{}
 					}
 					c.RequestsHistogram[protocol][float32(le)] = merge(c.RequestsHistogram[protocol][float32(le)], m.Values, timeseries.NanSum)
+					if route := m.Labels["route"]; protocol == "http" && route != "" {
+						if c.RequestsHistogramByRoute[route] == nil {
+							c.RequestsHistogramByRoute[route] = map[float32]*timeseries.TimeSeries{}
+						}
+						c.RequestsHistogramByRoute[route][float32(le)] = merge(c.RequestsHistogramByRoute[route][float32(le)], m.Values, timeseries.NanSum)
+					}
 				}
 			case "container_cpu_limit":
 				container.CpuLimit = merge(container.CpuLimit, m.Values, timeseries.Any)
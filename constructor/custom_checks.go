@@ -0,0 +1,31 @@
+package constructor
+
+import (
+	"context"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+)
+
+func (c *Constructor) loadCustomChecks(ctx context.Context, w *model.World, from, to timeseries.Time, step timeseries.Duration) {
+	checks := c.project.Settings.CustomChecks
+	if len(checks) == 0 || c.options[OptionDoNotLoadCustomChecks] {
+		return
+	}
+	for _, app := range w.Applications {
+		for i := range checks {
+			check := &checks[i]
+			if !check.Matches(app.Id, app.Category) {
+				continue
+			}
+			data := &model.CustomCheckData{Check: check}
+			query, err := check.RenderQuery(app.Id)
+			if err == nil {
+				data.Values, err = c.prom.QueryRange(ctx, query, from, to, step)
+			}
+			if err != nil {
+				data.Error = err.Error()
+			}
+			app.CustomChecks = append(app.CustomChecks, data)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package constructor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"net"
+)
+
+const elasticsearchHttpPort = "9200"
+
+func elasticsearch(instance *model.Instance, queryName string, m model.MetricValues) {
+	if instance == nil {
+		return
+	}
+	if instance.Elasticsearch == nil {
+		instance.Elasticsearch = model.NewElasticsearch()
+	}
+	es := instance.Elasticsearch
+	ls := m.Labels
+	values := m.Values
+	if cluster := ls["cluster"]; cluster != "" {
+		es.Cluster.Update(values, cluster)
+		if ls["host"] != "" && instance.ClusterName.Value() == "" {
+			instance.ClusterName.Update(values, cluster)
+		}
+	}
+	switch queryName {
+	case "elasticsearch_cluster_health_status":
+		es.Health.Update(values, ls["color"])
+	case "elasticsearch_unassigned_shards":
+		es.UnassignedShards = merge(es.UnassignedShards, values, timeseries.Any)
+	case "elasticsearch_heap_used_bytes":
+		es.HeapUsed = merge(es.HeapUsed, values, timeseries.Any)
+	case "elasticsearch_heap_max_bytes":
+		es.HeapMax = merge(es.HeapMax, values, timeseries.Any)
+	case "elasticsearch_indexing_rate":
+		es.IndexingRate = merge(es.IndexingRate, values, timeseries.Any)
+	case "elasticsearch_indexing_latency_seconds":
+		es.IndexingLatency = merge(es.IndexingLatency, values, timeseries.Any)
+	case "elasticsearch_search_rate":
+		es.SearchRate = merge(es.SearchRate, values, timeseries.Any)
+	case "elasticsearch_search_latency_seconds":
+		es.SearchLatency = merge(es.SearchLatency, values, timeseries.Any)
+	case "elasticsearch_rejected_tasks":
+		es.RejectedTasks[ls["type"]] = merge(es.RejectedTasks[ls["type"]], values, timeseries.Any)
+	}
+}
+
+func elasticsearchNodeLabels(ls model.Labels) model.Labels {
+	host := ls["host"]
+	if ip := net.ParseIP(host); ip == nil {
+		return ls
+	}
+	res := make(model.Labels, len(ls))
+	for k, v := range ls {
+		res[k] = v
+	}
+	res["instance"] = net.JoinHostPort(host, elasticsearchHttpPort)
+	return res
+}
@@ -0,0 +1,18 @@
+package constructor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestElasticsearchNodeLabels(t *testing.T) {
+	ls := model.Labels{"instance": "exporter:9114", "host": "10.0.0.1"}
+	res := elasticsearchNodeLabels(ls)
+	assert.Equal(t, "10.0.0.1:9200", res["instance"])
+	assert.Equal(t, "10.0.0.1", res["host"])
+	assert.Equal(t, "exporter:9114", ls["instance"])
+
+	ls = model.Labels{"instance": "exporter:9114", "host": "es-0"}
+	assert.Equal(t, "exporter:9114", elasticsearchNodeLabels(ls)["instance"])
+}
@@ -41,6 +41,10 @@ func calcUpDownEvents(app *model.Application) []*model.ApplicationEvent {
 			up = instance.Postgres.Up
 		case instance.Redis != nil && instance.Redis.Up != nil:
 			up = instance.Redis.Up
+		case instance.Mysql != nil && instance.Mysql.Up != nil:
+			up = instance.Mysql.Up
+		case instance.Mongodb != nil && instance.Mongodb.Up != nil:
+			up = instance.Mongodb.Up
 		default:
 			continue
 		}
@@ -135,6 +135,8 @@ func podLabels(metrics []model.MetricValues, pods map[string]*model.Instance) {
 		case m.Labels["label_k8s_enterprisedb_io_cluster"] != "":
 			cluster = m.Labels["label_k8s_enterprisedb_io_cluster"]
 			role = m.Labels["label_role"]
+		case m.Labels["label_app_kubernetes_io_name"] == "percona-server-mongodb": // the role is reported by mongodb-exporter
+			cluster = m.Labels["label_app_kubernetes_io_instance"]
 		default:
 			continue
 		}
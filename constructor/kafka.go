@@ -0,0 +1,29 @@
+package constructor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+)
+
+func kafka(instance *model.Instance, queryName string, m model.MetricValues) {
+	if instance == nil {
+		return
+	}
+	if instance.Kafka == nil {
+		instance.Kafka = model.NewKafka()
+	}
+	k := instance.Kafka
+	ls := m.Labels
+	values := m.Values
+	switch queryName {
+	case "kafka_broker_info":
+		k.Brokers[ls["address"]] = merge(k.Brokers[ls["address"]], values, timeseries.Any)
+	case "kafka_under_replicated_partitions":
+		k.UnderReplicatedPartitions[ls["topic"]] = merge(k.UnderReplicatedPartitions[ls["topic"]], values, timeseries.Any)
+	case "kafka_offline_partitions":
+		k.OfflinePartitions[ls["topic"]] = merge(k.OfflinePartitions[ls["topic"]], values, timeseries.Any)
+	case "kafka_consumergroup_lag":
+		key := model.KafkaConsumerGroupKey{Group: ls["consumergroup"], Topic: ls["topic"]}
+		k.ConsumerGroupLag[key] = merge(k.ConsumerGroupLag[key], values, timeseries.Any)
+	}
+}
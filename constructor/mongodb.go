@@ -0,0 +1,47 @@
+package constructor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+)
+
+func mongodb(instance *model.Instance, queryName string, m model.MetricValues) {
+	if instance == nil {
+		return
+	}
+	if instance.Mongodb == nil {
+		instance.Mongodb = model.NewMongodb()
+	}
+	mg := instance.Mongodb
+	values := m.Values
+	switch queryName {
+	case "mongodb_up":
+		mg.Up = merge(mg.Up, values, timeseries.Any)
+	case "mongodb_info":
+		mg.Version.Update(values, m.Labels["mongodb"])
+	case "mongodb_rs_state":
+		mg.State = merge(mg.State, values, timeseries.Any)
+		mg.ReplicaSet.Update(values, m.Labels["rs_nm"])
+		instance.MergeClusterRole("primary", mongodbStateIs(values, model.MongodbStatePrimary))
+		instance.MergeClusterRole("replica", mongodbStateIs(values, model.MongodbStateSecondary))
+	case "mongodb_rs_optime_seconds":
+		mg.LastAppliedOptime = merge(mg.LastAppliedOptime, values, timeseries.Any)
+	case "mongodb_connections":
+		mg.Connections = merge(mg.Connections, values, timeseries.Any)
+	case "mongodb_connections_available":
+		mg.AvailableConnections = merge(mg.AvailableConnections, values, timeseries.Any)
+	case "mongodb_ops":
+		mg.Ops = merge(mg.Ops, values, timeseries.Any)
+	case "mongodb_slow_ops":
+		mg.SlowOps = merge(mg.SlowOps, values, timeseries.Any)
+	}
+}
+
+func mongodbStateIs(state *timeseries.TimeSeries, s float32) *timeseries.TimeSeries {
+	return state.Map(func(t timeseries.Time, v float32) float32 {
+		if v == s {
+			return 1
+		}
+		return 0
+	})
+}
@@ -0,0 +1,25 @@
+package constructor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPodLabelsMongodb(t *testing.T) {
+	ts := timeseries.NewWithData(0, 30, []float32{1, 1})
+	pods := map[string]*model.Instance{"uid1": {Name: "mongo-rs0-0"}, "uid2": {Name: "mongo-rs0-1"}}
+	podLabels([]model.MetricValues{
+		{Labels: model.Labels{"uid": "uid1", "label_app_kubernetes_io_name": "percona-server-mongodb", "label_app_kubernetes_io_instance": "mongo"}, Values: ts},
+		{Labels: model.Labels{"uid": "uid2", "label_app_kubernetes_io_name": "mongodb", "label_app_kubernetes_io_instance": "mongo"}, Values: ts},
+	}, pods)
+	assert.Equal(t, "mongo", pods["uid1"].ClusterName.Value())
+	assert.Equal(t, "", pods["uid2"].ClusterName.Value())
+	assert.Equal(t, model.ClusterRoleNone, pods["uid1"].ClusterRoleLast())
+
+	pods["uid1"].UpdateClusterRole("primary", timeseries.NewWithData(0, 30, []float32{1, 0}))
+	pods["uid1"].UpdateClusterRole("replica", timeseries.NewWithData(0, 30, []float32{0, 1}))
+	assert.Equal(t, model.ClusterRoleReplica, pods["uid1"].ClusterRoleLast())
+	assert.Equal(t, "TimeSeries(0, 2, 30, [. 2])", pods["uid1"].ClusterRole().String())
+}
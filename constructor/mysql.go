@@ -0,0 +1,41 @@
+package constructor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+)
+
+func mysql(instance *model.Instance, queryName string, m model.MetricValues) {
+	if instance == nil {
+		return
+	}
+	if instance.Mysql == nil {
+		instance.Mysql = model.NewMysql()
+	}
+	my := instance.Mysql
+	values := m.Values
+	switch queryName {
+	case "mysql_up":
+		my.Up = merge(my.Up, values, timeseries.Any)
+	case "mysql_version_info":
+		my.Version.Update(values, m.Labels["version"])
+	case "mysql_connections":
+		my.Connections = merge(my.Connections, values, timeseries.Any)
+	case "mysql_max_connections":
+		my.MaxConnections = merge(my.MaxConnections, values, timeseries.Any)
+	case "mysql_replication_lag_seconds":
+		my.ReplicationLag = merge(my.ReplicationLag, values, timeseries.Any)
+	case "mysql_queries":
+		my.Queries = merge(my.Queries, values, timeseries.Any)
+	case "mysql_slow_queries":
+		my.SlowQueries = merge(my.SlowQueries, values, timeseries.Any)
+	case "mysql_statements_total":
+		my.StatementsCount = merge(my.StatementsCount, values, timeseries.Any)
+	case "mysql_statements_seconds_total":
+		my.StatementsTime = merge(my.StatementsTime, values, timeseries.Any)
+	case "mysql_innodb_row_lock_waits":
+		my.RowLockWaits = merge(my.RowLockWaits, values, timeseries.Any)
+	case "mysql_innodb_row_lock_time_seconds":
+		my.RowLockTime = merge(my.RowLockTime, values, timeseries.Any)
+	}
+}
@@ -12,6 +12,14 @@ const (
 	qApplicationCustomSLI                  = "application_custom_sli"
 	qRecordingRuleInboundRequestsTotal     = "rr_application_inbound_requests_total"
 	qRecordingRuleInboundRequestsHistogram = "rr_application_inbound_requests_histogram"
+
+	qRecordingRuleOutboundRequestsTotal     = "rr_application_outbound_requests_total"
+	qRecordingRuleOutboundRequestsHistogram = "rr_application_outbound_requests_histogram"
+
+	qRecordingRuleInboundRequestsByRouteTotal     = "rr_application_inbound_requests_by_route_total"
+	qRecordingRuleInboundRequestsByRouteHistogram = "rr_application_inbound_requests_by_route_histogram"
+
+	qRecordingRuleApplicationInfo = "rr_application_info"
 )
 
 var QUERIES = map[string]string{
@@ -143,6 +151,48 @@ var QUERIES = map[string]string{
 	"redis_commands_duration_seconds_total": `rate(redis_commands_duration_seconds_total[$RANGE])`,
 	"redis_commands_total":                  `rate(redis_commands_total[$RANGE])`,
 
+	"mysql_up":                           `mysql_up`,
+	"mysql_version_info":                 `mysql_version_info`,
+	"mysql_connections":                  `mysql_global_status_threads_connected`,
+	"mysql_max_connections":              `mysql_global_variables_max_connections`,
+	"mysql_replication_lag_seconds":      `mysql_slave_status_seconds_behind_master`,
+	"mysql_queries":                      `rate(mysql_global_status_queries[$RANGE])`,
+	"mysql_slow_queries":                 `rate(mysql_global_status_slow_queries[$RANGE])`,
+	"mysql_statements_total":             `sum without(schema, digest, digest_text) (rate(mysql_perf_schema_events_statements_total[$RANGE]))`,
+	"mysql_statements_seconds_total":     `sum without(schema, digest, digest_text) (rate(mysql_perf_schema_events_statements_seconds_total[$RANGE]))`,
+	"mysql_innodb_row_lock_waits":        `rate(mysql_global_status_innodb_row_lock_waits[$RANGE])`,
+	"mysql_innodb_row_lock_time_seconds": `rate(mysql_global_status_innodb_row_lock_time[$RANGE]) / 1000`,
+
+	"mongodb_up":                    `mongodb_up`,
+	"mongodb_info":                  `mongodb_version_info`,
+	"mongodb_rs_state":              `mongodb_rs_myState`,
+	"mongodb_rs_optime_seconds":     `mongodb_rs_members_optimeDate / 1000 and on(instance, member_idx) (mongodb_rs_members_self == 1)`,
+	"mongodb_connections":           `mongodb_ss_connections{conn_type="current"}`,
+	"mongodb_connections_available": `mongodb_ss_connections{conn_type="available"}`,
+	"mongodb_ops":                   `sum without(legacy_op_type) (rate(mongodb_ss_opcounters[$RANGE]))`,
+	"mongodb_slow_ops":              `sum without(database) (rate(mongodb_profile_slow_query_count[$RANGE]))`,
+
+	"kafka_broker_info":                 `kafka_broker_info`,
+	"kafka_under_replicated_partitions": `sum without(partition) (kafka_topic_partition_under_replicated_partition)`,
+	"kafka_offline_partitions":          `sum without(partition) (kafka_topic_partition_leader == bool -1)`,
+	"kafka_consumergroup_lag":           `kafka_consumergroup_lag_sum`,
+
+	"elasticsearch_cluster_health_status":    `elasticsearch_cluster_health_status == 1`,
+	"elasticsearch_unassigned_shards":        `elasticsearch_cluster_health_unassigned_shards`,
+	"elasticsearch_heap_used_bytes":          `elasticsearch_jvm_memory_used_bytes{area="heap"}`,
+	"elasticsearch_heap_max_bytes":           `elasticsearch_jvm_memory_max_bytes{area="heap"}`,
+	"elasticsearch_indexing_rate":            `rate(elasticsearch_indices_indexing_index_total[$RANGE])`,
+	"elasticsearch_indexing_latency_seconds": `rate(elasticsearch_indices_indexing_index_time_seconds_total[$RANGE]) / rate(elasticsearch_indices_indexing_index_total[$RANGE])`,
+	"elasticsearch_search_rate":              `rate(elasticsearch_indices_search_query_total[$RANGE])`,
+	"elasticsearch_search_latency_seconds":   `rate(elasticsearch_indices_search_query_time_seconds[$RANGE]) / rate(elasticsearch_indices_search_query_total[$RANGE])`,
+	"elasticsearch_rejected_tasks":           `rate(elasticsearch_thread_pool_rejected_count[$RANGE])`,
+
+	"rabbitmq_queue_messages_ready":   `rabbitmq_queue_messages_ready`,
+	"rabbitmq_queue_messages_unacked": `rabbitmq_queue_messages_unacked or rabbitmq_queue_messages_unacknowledged`,
+	"rabbitmq_queue_consumers":        `rabbitmq_queue_consumers`,
+	"rabbitmq_queue_publish_rate":     `rate(rabbitmq_queue_messages_published_total[$RANGE])`,
+	"rabbitmq_queue_deliver_rate":     `rate(rabbitmq_queue_messages_delivered_total[$RANGE])`,
+
 	"container_jvm_info":                        `container_jvm_info`,
 	"container_jvm_heap_size_bytes":             `container_jvm_heap_size_bytes`,
 	"container_jvm_heap_used_bytes":             `container_jvm_heap_used_bytes`,
@@ -151,7 +201,22 @@ var QUERIES = map[string]string{
 	"container_jvm_safepoint_time_seconds":      `rate(container_jvm_safepoint_time_seconds[$RANGE])`,
 }
 
+var recordingRulesWithoutRaw = map[string]bool{
+	qRecordingRuleInboundRequestsByRouteTotal:     true,
+	qRecordingRuleInboundRequestsByRouteHistogram: true,
+	qRecordingRuleApplicationInfo:                 true,
+}
+
 var RecordingRules = map[string]func(p *db.Project, w *model.World) []model.MetricValues{
+	qRecordingRuleApplicationInfo: func(p *db.Project, w *model.World) []model.MetricValues {
+		ts := timeseries.New(w.Ctx.From, int(w.Ctx.To.Sub(w.Ctx.From)/w.Ctx.Step)+1, w.Ctx.Step).WithNewValue(1)
+		res := make([]model.MetricValues, 0, len(w.Applications))
+		for _, app := range w.Applications {
+			ls := model.Labels{"application": app.Id.String()}
+			res = append(res, model.MetricValues{Labels: ls, LabelsHash: promModel.LabelsToSignature(ls), Values: ts})
+		}
+		return res
+	},
 
 	qRecordingRuleInboundRequestsTotal: func(p *db.Project, w *model.World) []model.MetricValues {
 		var res []model.MetricValues
@@ -228,4 +293,168 @@ var RecordingRules = map[string]func(p *db.Project, w *model.World) []model.Metr
 		}
 		return res
 	},
+
+	qRecordingRuleInboundRequestsByRouteTotal: func(p *db.Project, w *model.World) []model.MetricValues {
+		var res []model.MetricValues
+		for _, app := range w.Applications {
+			byClient := app.GetClientsConnections()
+			if len(byClient) == 0 {
+				continue
+			}
+			appCategory := model.CalcApplicationCategory(app.Id, p.Settings.ApplicationCategories)
+			sum := map[[2]string]*timeseries.Aggregate{}
+			for client, connections := range byClient {
+				clientCategory := model.CalcApplicationCategory(client, p.Settings.ApplicationCategories)
+				if !appCategory.Monitoring() && clientCategory.Monitoring() {
+					continue
+				}
+				for _, c := range connections {
+					for route, byStatus := range c.RequestsCountByRoute {
+						for status, ts := range byStatus {
+							k := [2]string{route, status}
+							if sum[k] == nil {
+								sum[k] = timeseries.NewAggregate(timeseries.NanSum)
+							}
+							sum[k].Add(ts)
+						}
+					}
+				}
+			}
+			appId := app.Id.String()
+			for k, agg := range sum {
+				ts := agg.Get()
+				if !ts.IsEmpty() {
+					ls := model.Labels{"application": appId, "route": k[0], "status": k[1]}
+					res = append(res, model.MetricValues{Labels: ls, LabelsHash: promModel.LabelsToSignature(ls), Values: ts})
+				}
+			}
+		}
+		return res
+	},
+
+	qRecordingRuleInboundRequestsByRouteHistogram: func(p *db.Project, w *model.World) []model.MetricValues {
+		type key struct {
+			route string
+			le    float32
+		}
+		var res []model.MetricValues
+		for _, app := range w.Applications {
+			byClient := app.GetClientsConnections()
+			if len(byClient) == 0 {
+				continue
+			}
+			appCategory := model.CalcApplicationCategory(app.Id, p.Settings.ApplicationCategories)
+			sum := map[key]*timeseries.Aggregate{}
+			for client, connections := range byClient {
+				clientCategory := model.CalcApplicationCategory(client, p.Settings.ApplicationCategories)
+				if !appCategory.Monitoring() && clientCategory.Monitoring() {
+					continue
+				}
+				for _, c := range connections {
+					for route, byLe := range c.RequestsHistogramByRoute {
+						for le, ts := range byLe {
+							k := key{route: route, le: le}
+							if sum[k] == nil {
+								sum[k] = timeseries.NewAggregate(timeseries.NanSum)
+							}
+							sum[k].Add(ts)
+						}
+					}
+				}
+			}
+			appId := app.Id.String()
+			for k, agg := range sum {
+				ts := agg.Get()
+				if !ts.IsEmpty() {
+					ls := model.Labels{"application": appId, "route": k.route, "le": fmt.Sprintf("%f", k.le)}
+					res = append(res, model.MetricValues{Labels: ls, LabelsHash: promModel.LabelsToSignature(ls), Values: ts})
+				}
+			}
+		}
+		return res
+	},
+
+	qRecordingRuleOutboundRequestsTotal: func(p *db.Project, w *model.World) []model.MetricValues {
+		var res []model.MetricValues
+		for _, app := range w.Applications {
+			upstreams := configuredUpstreams(w, app)
+			if len(upstreams) == 0 {
+				continue
+			}
+			sum := map[[2]string]*timeseries.Aggregate{}
+			for upstream, connections := range app.GetUpstreamConnections() {
+				if !upstreams[upstream] {
+					continue
+				}
+				for _, c := range connections {
+					for _, byStatus := range c.RequestsCount {
+						for status, ts := range byStatus {
+							k := [2]string{upstream.String(), status}
+							if sum[k] == nil {
+								sum[k] = timeseries.NewAggregate(timeseries.NanSum)
+							}
+							sum[k].Add(ts)
+						}
+					}
+				}
+			}
+			appId := app.Id.String()
+			for k, agg := range sum {
+				ts := agg.Get()
+				if !ts.IsEmpty() {
+					ls := model.Labels{"application": appId, "upstream": k[0], "status": k[1]}
+					res = append(res, model.MetricValues{Labels: ls, LabelsHash: promModel.LabelsToSignature(ls), Values: ts})
+				}
+			}
+		}
+		return res
+	},
+
+	qRecordingRuleOutboundRequestsHistogram: func(p *db.Project, w *model.World) []model.MetricValues {
+		type key struct {
+			upstream string
+			le       float32
+		}
+		var res []model.MetricValues
+		for _, app := range w.Applications {
+			upstreams := configuredUpstreams(w, app)
+			if len(upstreams) == 0 {
+				continue
+			}
+			sum := map[key]*timeseries.Aggregate{}
+			for upstream, connections := range app.GetUpstreamConnections() {
+				if !upstreams[upstream] {
+					continue
+				}
+				for _, c := range connections {
+					for _, byLe := range c.RequestsHistogram {
+						for le, ts := range byLe {
+							k := key{upstream: upstream.String(), le: le}
+							if sum[k] == nil {
+								sum[k] = timeseries.NewAggregate(timeseries.NanSum)
+							}
+							sum[k].Add(ts)
+						}
+					}
+				}
+			}
+			appId := app.Id.String()
+			for k, agg := range sum {
+				ts := agg.Get()
+				if !ts.IsEmpty() {
+					ls := model.Labels{"application": appId, "upstream": k.upstream, "le": fmt.Sprintf("%f", k.le)}
+					res = append(res, model.MetricValues{Labels: ls, LabelsHash: promModel.LabelsToSignature(ls), Values: ts})
+				}
+			}
+		}
+		return res
+	},
+}
+
+func configuredUpstreams(w *model.World, app *model.Application) map[model.ApplicationId]bool {
+	res := map[model.ApplicationId]bool{}
+	for _, cfg := range w.CheckConfigs.GetDependencies(app.Id) {
+		res[cfg.Upstream] = true
+	}
+	return res
 }
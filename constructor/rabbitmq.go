@@ -0,0 +1,37 @@
+package constructor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+)
+
+func rabbitmq(instance *model.Instance, queryName string, m model.MetricValues) {
+	if instance == nil {
+		return
+	}
+	if instance.Rabbitmq == nil {
+		instance.Rabbitmq = model.NewRabbitmq()
+	}
+	key := model.RabbitmqQueueKey{Vhost: m.Labels["vhost"], Name: m.Labels["queue"]}
+	if key.Name == "" {
+		return
+	}
+	q := instance.Rabbitmq.Queues[key]
+	if q == nil {
+		q = &model.RabbitmqQueue{}
+		instance.Rabbitmq.Queues[key] = q
+	}
+	values := m.Values
+	switch queryName {
+	case "rabbitmq_queue_messages_ready":
+		q.Ready = merge(q.Ready, values, timeseries.Any)
+	case "rabbitmq_queue_messages_unacked":
+		q.Unacked = merge(q.Unacked, values, timeseries.Any)
+	case "rabbitmq_queue_consumers":
+		q.Consumers = merge(q.Consumers, values, timeseries.Any)
+	case "rabbitmq_queue_publish_rate":
+		q.PublishRate = merge(q.PublishRate, values, timeseries.Any)
+	case "rabbitmq_queue_deliver_rate":
+		q.DeliverRate = merge(q.DeliverRate, values, timeseries.Any)
+	}
+}
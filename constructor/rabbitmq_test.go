@@ -0,0 +1,21 @@
+package constructor
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRabbitmqQueueKeys(t *testing.T) {
+	ts := timeseries.NewWithData(0, 30, []float32{1})
+	instance := &model.Instance{Name: "rabbitmq-0"}
+	rabbitmq(instance, "rabbitmq_queue_messages_ready", model.MetricValues{Labels: model.Labels{"vhost": "/", "queue": "orders"}, Values: ts})
+	rabbitmq(instance, "rabbitmq_queue_messages_ready", model.MetricValues{Labels: model.Labels{"vhost": "billing", "queue": "orders"}, Values: ts})
+	rabbitmq(instance, "rabbitmq_queue_consumers", model.MetricValues{Labels: model.Labels{"vhost": "/", "queue": "orders"}, Values: ts})
+	rabbitmq(instance, "rabbitmq_queue_messages_ready", model.MetricValues{Labels: model.Labels{"vhost": "/"}, Values: ts})
+
+	assert.Len(t, instance.Rabbitmq.Queues, 2)
+	assert.Contains(t, instance.Rabbitmq.Queues, model.RabbitmqQueueKey{Vhost: "/", Name: "orders"})
+	assert.Contains(t, instance.Rabbitmq.Queues, model.RabbitmqQueueKey{Vhost: "billing", Name: "orders"})
+}
@@ -9,91 +9,132 @@ import (
 	"strings"
 )
 
+type customSLIKey struct {
+	appId model.ApplicationId
+	idx   int
+}
+
 func (c *Constructor) loadSLIs(w *model.World, metrics map[string][]model.MetricValues) {
 	builtinAvailabilityCur := builtinAvailability(metrics[qRecordingRuleInboundRequestsTotal])
 	builtinAvailabilityRaw := builtinAvailability(metrics[qRecordingRuleInboundRequestsTotal+"_raw"])
 	builtinLatencyCur := builtinLatency(metrics[qRecordingRuleInboundRequestsHistogram])
 	builtinLatencyRaw := builtinLatency(metrics[qRecordingRuleInboundRequestsHistogram+"_raw"])
 
-	customAvailabilityCur := map[model.ApplicationId]availabilitySlis{}
-	customAvailabilityRaw := map[model.ApplicationId]availabilitySlis{}
-	customLatencyCur := map[model.ApplicationId][]model.HistogramBucket{}
-	customLatencyRaw := map[model.ApplicationId][]model.HistogramBucket{}
+	customAvailabilityCur := map[customSLIKey]availabilitySlis{}
+	customAvailabilityRaw := map[customSLIKey]availabilitySlis{}
+	customLatencyCur := map[customSLIKey][]model.HistogramBucket{}
+	customLatencyRaw := map[customSLIKey][]model.HistogramBucket{}
 	loadCustomSLIs(metrics, customAvailabilityCur, customAvailabilityRaw, customLatencyCur, customLatencyRaw)
+	routes := builtinRoutes(metrics[qRecordingRuleInboundRequestsByRouteTotal], metrics[qRecordingRuleInboundRequestsByRouteHistogram])
+	outboundAvailabilityCur := outboundAvailability(metrics[qRecordingRuleOutboundRequestsTotal])
+	outboundAvailabilityRaw := outboundAvailability(metrics[qRecordingRuleOutboundRequestsTotal+"_raw"])
+	outboundLatencyCur := outboundLatency(metrics[qRecordingRuleOutboundRequestsHistogram])
+	outboundLatencyRaw := outboundLatency(metrics[qRecordingRuleOutboundRequestsHistogram+"_raw"])
 
 	for _, app := range w.Applications {
-		availabilityCfg, _ := w.CheckConfigs.GetAvailability(app.Id)
-		if availabilityCfg.Custom {
-			cur, raw := customAvailabilityCur[app.Id], customAvailabilityRaw[app.Id]
-			app.AvailabilitySLIs = append(app.AvailabilitySLIs, &model.AvailabilitySLI{
-				Config:        availabilityCfg,
+		app.RouteSLIs = routes[app.Id]
+
+		for _, cfg := range w.CheckConfigs.GetDependencies(app.Id) {
+			k := dependencyKey{appId: app.Id, upstream: cfg.Upstream}
+			sli := &model.DependencySLI{Upstream: cfg.Upstream}
+			cur, raw := outboundAvailabilityCur[k], outboundAvailabilityRaw[k]
+			sli.Availability = &model.AvailabilitySLI{
+				Config:        model.CheckConfigSLOAvailability{Name: cfg.Upstream.Name, ObjectivePercentage: cfg.AvailabilityObjectivePercentage},
 				TotalRequests: cur.total, TotalRequestsRaw: raw.total,
 				FailedRequests: cur.failed, FailedRequestsRaw: raw.failed,
-			})
-		} else {
-			cur, raw := builtinAvailabilityCur[app.Id], builtinAvailabilityRaw[app.Id]
-			if !cur.total.IsEmpty() || !raw.total.IsEmpty() {
+			}
+			if cfg.LatencyObjectiveBucket > 0 {
+				sli.Latency = &model.LatencySLI{
+					Config:    model.CheckConfigSLOLatency{Name: cfg.Upstream.Name, ObjectiveBucket: cfg.LatencyObjectiveBucket, ObjectivePercentage: cfg.LatencyObjectivePercentage},
+					Histogram: outboundLatencyCur[k], HistogramRaw: outboundLatencyRaw[k],
+				}
+			}
+			app.DependencySLIs = append(app.DependencySLIs, sli)
+		}
+
+		availabilityCfgs, _ := w.CheckConfigs.GetAvailabilityAll(app.Id)
+		for i, availabilityCfg := range availabilityCfgs {
+			if availabilityCfg.Custom {
+				k := customSLIKey{appId: app.Id, idx: i}
+				cur, raw := customAvailabilityCur[k], customAvailabilityRaw[k]
 				app.AvailabilitySLIs = append(app.AvailabilitySLIs, &model.AvailabilitySLI{
 					Config:        availabilityCfg,
 					TotalRequests: cur.total, TotalRequestsRaw: raw.total,
 					FailedRequests: cur.failed, FailedRequestsRaw: raw.failed,
 				})
+			} else {
+				cur, raw := builtinAvailabilityCur[app.Id], builtinAvailabilityRaw[app.Id]
+				if !cur.total.IsEmpty() || !raw.total.IsEmpty() {
+					app.AvailabilitySLIs = append(app.AvailabilitySLIs, &model.AvailabilitySLI{
+						Config:        availabilityCfg,
+						TotalRequests: cur.total, TotalRequestsRaw: raw.total,
+						FailedRequests: cur.failed, FailedRequestsRaw: raw.failed,
+					})
+				}
 			}
 		}
 
-		latencyCfg, _ := w.CheckConfigs.GetLatency(app.Id, app.Category)
-		if latencyCfg.Custom {
-			cur, raw := customLatencyCur[app.Id], customLatencyRaw[app.Id]
-			app.LatencySLIs = append(app.LatencySLIs, &model.LatencySLI{
-				Config:    latencyCfg,
-				Histogram: cur, HistogramRaw: raw,
-			})
-		} else {
-			cur, raw := builtinLatencyCur[app.Id], builtinLatencyRaw[app.Id]
-			if len(cur) > 0 || len(raw) > 0 {
+		latencyCfgs, _ := w.CheckConfigs.GetLatencyAll(app.Id, app.Category)
+		for i, latencyCfg := range latencyCfgs {
+			if latencyCfg.Custom {
+				k := customSLIKey{appId: app.Id, idx: i}
+				cur, raw := customLatencyCur[k], customLatencyRaw[k]
 				app.LatencySLIs = append(app.LatencySLIs, &model.LatencySLI{
 					Config:    latencyCfg,
 					Histogram: cur, HistogramRaw: raw,
 				})
+			} else {
+				cur, raw := builtinLatencyCur[app.Id], builtinLatencyRaw[app.Id]
+				if len(cur) > 0 || len(raw) > 0 {
+					app.LatencySLIs = append(app.LatencySLIs, &model.LatencySLI{
+						Config:    latencyCfg,
+						Histogram: cur, HistogramRaw: raw,
+					})
+				}
 			}
 		}
 	}
 }
 
 func loadCustomSLIs(metrics map[string][]model.MetricValues,
-	availabilityCur, availabilityRaw map[model.ApplicationId]availabilitySlis,
-	latencyCur, latencyRaw map[model.ApplicationId][]model.HistogramBucket,
+	availabilityCur, availabilityRaw map[customSLIKey]availabilitySlis,
+	latencyCur, latencyRaw map[customSLIKey][]model.HistogramBucket,
 ) {
 	for queryName, values := range metrics {
 		if len(values) == 0 || !strings.HasPrefix(queryName, qApplicationCustomSLI) {
 			continue
 		}
 		parts := strings.Split(queryName, "/")
-		if len(parts) != 3 {
+		if len(parts) != 4 {
 			continue
 		}
 		appId, _ := model.NewApplicationIdFromString(parts[1])
-		switch parts[2] {
+		idx, err := strconv.Atoi(parts[2])
+		if err != nil {
+			continue
+		}
+		k := customSLIKey{appId: appId, idx: idx}
+		switch parts[3] {
 		case "total_requests":
-			a := availabilityCur[appId]
+			a := availabilityCur[k]
 			a.total = values[0].Values
-			availabilityCur[appId] = a
+			availabilityCur[k] = a
 		case "total_requests_raw":
-			a := availabilityRaw[appId]
+			a := availabilityRaw[k]
 			a.total = values[0].Values
-			availabilityRaw[appId] = a
+			availabilityRaw[k] = a
 		case "failed_requests":
-			a := availabilityCur[appId]
+			a := availabilityCur[k]
 			a.failed = values[0].Values
-			availabilityCur[appId] = a
+			availabilityCur[k] = a
 		case "failed_requests_raw":
-			a := availabilityRaw[appId]
+			a := availabilityRaw[k]
 			a.failed = values[0].Values
-			availabilityRaw[appId] = a
+			availabilityRaw[k] = a
 		case "requests_histogram":
-			latencyCur[appId] = histogramBuckets(values)
+			latencyCur[k] = histogramBuckets(values)
 		case "requests_histogram_raw":
-			latencyRaw[appId] = histogramBuckets(values)
+			latencyRaw[k] = histogramBuckets(values)
 		}
 	}
 }
@@ -157,6 +198,128 @@ func builtinLatency(values []model.MetricValues) map[model.ApplicationId][]model
 	return res
 }
 
+func builtinRoutes(totalValues, histogramValues []model.MetricValues) map[model.ApplicationId][]*model.RouteSLI {
+	type key struct {
+		appId model.ApplicationId
+		route string
+	}
+	byRoute := map[key]*model.RouteSLI{}
+	get := func(mv model.MetricValues) *model.RouteSLI {
+		appId, err := model.NewApplicationIdFromString(mv.Labels["application"])
+		if err != nil {
+			klog.Warningln(err)
+			return nil
+		}
+		k := key{appId: appId, route: mv.Labels["route"]}
+		r := byRoute[k]
+		if r == nil {
+			r = &model.RouteSLI{Route: k.route}
+			byRoute[k] = r
+		}
+		return r
+	}
+
+	totals := map[*model.RouteSLI]*timeseries.Aggregate{}
+	failed := map[*model.RouteSLI]*timeseries.Aggregate{}
+	for _, mv := range totalValues {
+		r := get(mv)
+		if r == nil {
+			continue
+		}
+		if totals[r] == nil {
+			totals[r] = timeseries.NewAggregate(timeseries.NanSum)
+			failed[r] = timeseries.NewAggregate(timeseries.NanSum)
+		}
+		totals[r].Add(mv.Values)
+		if model.IsRequestStatusFailed(mv.Labels["status"]) {
+			failed[r].Add(mv.Values)
+		}
+	}
+	for r, agg := range totals {
+		r.TotalRequests = agg.Get()
+		r.FailedRequests = failed[r].Get()
+	}
+
+	histograms := map[*model.RouteSLI][]model.MetricValues{}
+	for _, mv := range histogramValues {
+		if r := get(mv); r != nil {
+			histograms[r] = append(histograms[r], mv)
+		}
+	}
+	for r, mvs := range histograms {
+		r.Histogram = histogramBuckets(mvs)
+	}
+
+	res := map[model.ApplicationId][]*model.RouteSLI{}
+	for k, r := range byRoute {
+		res[k.appId] = append(res[k.appId], r)
+	}
+	return res
+}
+
+type dependencyKey struct {
+	appId    model.ApplicationId
+	upstream model.ApplicationId
+}
+
+func getDependencyKey(ls model.Labels) (dependencyKey, bool) {
+	appId, err := model.NewApplicationIdFromString(ls["application"])
+	if err != nil {
+		klog.Warningln(err)
+		return dependencyKey{}, false
+	}
+	upstream, err := model.NewApplicationIdFromString(ls["upstream"])
+	if err != nil {
+		klog.Warningln(err)
+		return dependencyKey{}, false
+	}
+	return dependencyKey{appId: appId, upstream: upstream}, true
+}
+
+func outboundAvailability(values []model.MetricValues) map[dependencyKey]availabilitySlis {
+	if len(values) == 0 {
+		return nil
+	}
+	totals := map[dependencyKey]*timeseries.Aggregate{}
+	failed := map[dependencyKey]*timeseries.Aggregate{}
+	for _, mv := range values {
+		k, ok := getDependencyKey(mv.Labels)
+		if !ok {
+			continue
+		}
+		if totals[k] == nil {
+			totals[k] = timeseries.NewAggregate(timeseries.NanSum)
+			failed[k] = timeseries.NewAggregate(timeseries.NanSum)
+		}
+		totals[k].Add(mv.Values)
+		if model.IsRequestStatusFailed(mv.Labels["status"]) {
+			failed[k].Add(mv.Values)
+		}
+	}
+	res := map[dependencyKey]availabilitySlis{}
+	for k, total := range totals {
+		res[k] = availabilitySlis{total: total.Get(), failed: failed[k].Get()}
+	}
+	return res
+}
+
+func outboundLatency(values []model.MetricValues) map[dependencyKey][]model.HistogramBucket {
+	if len(values) == 0 {
+		return nil
+	}
+	byKey := map[dependencyKey][]model.MetricValues{}
+	for _, mv := range values {
+		if k, ok := getDependencyKey(mv.Labels); ok {
+			byKey[k] = append(byKey[k], mv)
+		}
+	}
+	res := map[dependencyKey][]model.HistogramBucket{}
+	for k, mvs := range byKey {
+		res[k] = histogramBuckets(mvs)
+	}
+	return res
+}
+
 func histogramBuckets(values []model.MetricValues) []model.HistogramBucket {
 	buckets := make([]model.HistogramBucket, 0, len(values))
 	for _, m := range values {
@@ -0,0 +1,111 @@
+package constructor
+
+import (
+	"encoding/json"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sort"
+	"testing"
+)
+
+func TestBuiltinRoutes(t *testing.T) {
+	catalog := model.NewApplicationId("default", model.ApplicationKindDeployment, "catalog")
+	orders := model.NewApplicationId("default", model.ApplicationKindDeployment, "orders")
+	mv := func(ls model.Labels, values ...float32) model.MetricValues {
+		return model.MetricValues{Labels: ls, Values: timeseries.NewWithData(0, 30, values)}
+	}
+	totals := []model.MetricValues{
+		mv(model.Labels{"application": catalog.String(), "route": "/api/items", "status": "200"}, 10, 10),
+		mv(model.Labels{"application": catalog.String(), "route": "/api/items", "status": "500"}, 1, 2),
+		mv(model.Labels{"application": catalog.String(), "route": "/api/items", "status": "failed"}, 1, 1),
+		mv(model.Labels{"application": catalog.String(), "route": "/api/cart", "status": "200"}, 5, 5),
+		mv(model.Labels{"application": orders.String(), "route": "/api/orders", "status": "404"}, 3, 3),
+		mv(model.Labels{"application": "invalid", "route": "/", "status": "200"}, 1, 1),
+	}
+	histograms := []model.MetricValues{
+		mv(model.Labels{"application": catalog.String(), "route": "/api/items", "le": "0.500000"}, 8, 9),
+		mv(model.Labels{"application": catalog.String(), "route": "/api/items", "le": "0.100000"}, 5, 6),
+		mv(model.Labels{"application": orders.String(), "route": "/api/payments", "le": "0.100000"}, 1, 1),
+	}
+
+	res := builtinRoutes(totals, histograms)
+	require.Len(t, res, 2)
+
+	byRoute := func(appId model.ApplicationId) map[string]*model.RouteSLI {
+		m := map[string]*model.RouteSLI{}
+		for _, r := range res[appId] {
+			m[r.Route] = r
+		}
+		return m
+	}
+
+	c := byRoute(catalog)
+	require.Len(t, c, 2)
+	assert.Equal(t, "TimeSeries(0, 2, 30, [12 13])", c["/api/items"].TotalRequests.String())
+	assert.Equal(t, "TimeSeries(0, 2, 30, [2 3])", c["/api/items"].FailedRequests.String())
+	require.Len(t, c["/api/items"].Histogram, 2)
+	assert.Equal(t, float32(0.1), c["/api/items"].Histogram[0].Le)
+	assert.Equal(t, float32(0.5), c["/api/items"].Histogram[1].Le)
+	assert.Equal(t, "TimeSeries(0, 2, 30, [5 5])", c["/api/cart"].TotalRequests.String())
+	assert.True(t, c["/api/cart"].FailedRequests.IsEmpty())
+	assert.Empty(t, c["/api/cart"].Histogram)
+
+	o := byRoute(orders)
+	routes := make([]string, 0, len(o))
+	for r := range o {
+		routes = append(routes, r)
+	}
+	sort.Strings(routes)
+	assert.Equal(t, []string{"/api/orders", "/api/payments"}, routes)
+	assert.True(t, o["/api/orders"].FailedRequests.IsEmpty())
+	assert.True(t, o["/api/payments"].TotalRequests.IsEmpty())
+}
+
+func TestOutboundRecordingRules(t *testing.T) {
+	frontend := model.NewApplicationId("default", model.ApplicationKindDeployment, "frontend")
+	payments := model.NewApplicationId("external", model.ApplicationKindExternalService, "payments")
+	catalog := model.NewApplicationId("default", model.ApplicationKindDeployment, "catalog")
+
+	connection := func(upstream model.ApplicationId) *model.Connection {
+		return &model.Connection{
+			RemoteInstance: &model.Instance{OwnerId: upstream},
+			RequestsCount: map[model.Protocol]map[string]*timeseries.TimeSeries{
+				"http": {"200": timeseries.NewWithData(0, 30, []float32{10, 10})},
+			},
+			RequestsHistogram: map[model.Protocol]map[float32]*timeseries.TimeSeries{
+				"http": {0.1: timeseries.NewWithData(0, 30, []float32{8, 8})},
+			},
+		}
+	}
+	deps, err := json.Marshal([]model.CheckConfigSLODependency{{Upstream: payments, AvailabilityObjectivePercentage: 99}})
+	require.NoError(t, err)
+	w := &model.World{
+		CheckConfigs: model.CheckConfigs{frontend: {model.Checks.SLODependency.Id: deps}},
+		Applications: []*model.Application{
+			{Id: frontend, Instances: []*model.Instance{{OwnerId: frontend, Upstreams: []*model.Connection{connection(payments), connection(catalog)}}}},
+			{Id: catalog, Instances: []*model.Instance{{OwnerId: catalog, Upstreams: []*model.Connection{connection(payments)}}}},
+		},
+	}
+
+	for _, name := range []string{qRecordingRuleOutboundRequestsTotal, qRecordingRuleOutboundRequestsHistogram} {
+		res := RecordingRules[name](nil, w)
+		require.Len(t, res, 1, name)
+		assert.Equal(t, frontend.String(), res[0].Labels["application"], name)
+		assert.Equal(t, payments.String(), res[0].Labels["upstream"], name)
+	}
+}
+
+func TestApplicationInfoRecordingRule(t *testing.T) {
+	frontend := model.NewApplicationId("default", model.ApplicationKindDeployment, "frontend")
+	catalog := model.NewApplicationId("default", model.ApplicationKindDeployment, "catalog")
+	w := model.NewWorld(0, 60, 30)
+	w.Applications = []*model.Application{model.NewApplication(frontend), model.NewApplication(catalog)}
+
+	res := RecordingRules[qRecordingRuleApplicationInfo](nil, w)
+	require.Len(t, res, 2)
+	assert.Equal(t, frontend.String(), res[0].Labels["application"])
+	assert.Equal(t, catalog.String(), res[1].Labels["application"])
+	assert.Equal(t, "TimeSeries(0, 3, 30, [1 1 1])", res[0].Values.String())
+}
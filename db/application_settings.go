@@ -10,6 +10,7 @@ import (
 
 type ApplicationSettings struct {
 	Pyroscope *ApplicationSettingsPyroscope `json:"pyroscope,omitempty"`
+	Kafka     *ApplicationSettingsKafka     `json:"kafka,omitempty"`
 }
 
 func (s *ApplicationSettings) Migrate(m *Migrator) error {
@@ -26,6 +27,10 @@ type ApplicationSettingsPyroscope struct {
 	Application string `json:"application"`
 }
 
+type ApplicationSettingsKafka struct {
+	ConsumerGroups []string `json:"consumer_groups"`
+}
+
 func (db *DB) GetApplicationSettings(projectId ProjectId, appId model.ApplicationId) (*ApplicationSettings, error) {
 	var settings sql.NullString
 	err := db.db.QueryRow(
@@ -42,6 +47,32 @@ func (db *DB) GetApplicationSettings(projectId ProjectId, appId model.Applicatio
 	return res, nil
 }
 
+func (db *DB) GetApplicationsSettings(projectId ProjectId) (map[model.ApplicationId]*ApplicationSettings, error) {
+	rows, err := db.db.Query("SELECT application_id, settings FROM application_settings WHERE project_id = $1", projectId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	res := map[model.ApplicationId]*ApplicationSettings{}
+	for rows.Next() {
+		var appId model.ApplicationId
+		var settings sql.NullString
+		if err := rows.Scan(&appId, &settings); err != nil {
+			return nil, err
+		}
+		var s *ApplicationSettings
+		if err := unmarshal(settings.String, &s); err != nil {
+			return nil, err
+		}
+		if s != nil {
+			res[appId] = s
+		}
+	}
+	return res, nil
+}
+
 func (db *DB) SaveApplicationSetting(projectId ProjectId, appId model.ApplicationId, s any) error {
 	as, err := db.GetApplicationSettings(projectId, appId)
 	if err != nil {
@@ -56,6 +87,8 @@ func (db *DB) SaveApplicationSetting(projectId ProjectId, appId model.Applicatio
 	case *ApplicationSettingsPyroscope:
 		klog.Infoln(v)
 		as.Pyroscope = v
+	case *ApplicationSettingsKafka:
+		as.Kafka = v
 	default:
 		return fmt.Errorf("unsupported type: %T", s)
 	}
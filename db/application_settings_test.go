@@ -0,0 +1,27 @@
+package db
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetApplicationsSettings(t *testing.T) {
+	db, projectId := newTestProject(t)
+	catalog := model.NewApplicationId("default", model.ApplicationKindDeployment, "catalog")
+	billing := model.NewApplicationId("default", model.ApplicationKindDeployment, "billing")
+
+	require.NoError(t, db.SaveApplicationSetting(projectId, catalog, &ApplicationSettingsPyroscope{Application: "catalog"}))
+	require.NoError(t, db.SaveApplicationSetting(projectId, billing, &ApplicationSettingsKafka{ConsumerGroups: []string{"invoices"}}))
+	require.NoError(t, db.SaveApplicationSetting(projectId, catalog, &ApplicationSettingsKafka{ConsumerGroups: []string{"catalog-sync"}}))
+
+	res, err := db.GetApplicationsSettings(projectId)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.NotNil(t, res[catalog].Pyroscope)
+	assert.Equal(t, "catalog", res[catalog].Pyroscope.Application)
+	assert.Equal(t, []string{"catalog-sync"}, res[catalog].Kafka.ConsumerGroups)
+	assert.Nil(t, res[billing].Pyroscope)
+	assert.Equal(t, []string{"invoices"}, res[billing].Kafka.ConsumerGroups)
+}
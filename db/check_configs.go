@@ -51,8 +51,15 @@ func (db *DB) GetCheckConfigs(projectId ProjectId) (model.CheckConfigs, error) {
 
 func (db *DB) SaveCheckConfig(projectId ProjectId, appId model.ApplicationId, checkId model.CheckId, cfg any) error {
 	appIdStr := appId.String()
+	tx, err := db.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
 	var configs sql.NullString
-	err := db.db.QueryRow("SELECT configs FROM check_configs WHERE project_id = $1 AND application_id = $2", projectId, appIdStr).Scan(&configs)
+	err = tx.QueryRow("SELECT configs FROM check_configs WHERE project_id = $1 AND application_id = $2", projectId, appIdStr).Scan(&configs)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return err
 	}
@@ -66,6 +73,7 @@ func (db *DB) SaveCheckConfig(projectId ProjectId, appId model.ApplicationId, ch
 	if err != nil {
 		return err
 	}
+	old := string(cs[checkId])
 	if string(c) == "null" {
 		delete(cs, checkId)
 	} else {
@@ -75,12 +83,22 @@ func (db *DB) SaveCheckConfig(projectId ProjectId, appId model.ApplicationId, ch
 	if err != nil {
 		return err
 	}
-	res, err := db.db.Exec("UPDATE check_configs SET configs = $1 WHERE project_id = $2 AND application_id = $3", string(data), projectId, appIdStr)
+	res, err := tx.Exec("UPDATE check_configs SET configs = $1 WHERE project_id = $2 AND application_id = $3", string(data), projectId, appIdStr)
+	if err != nil {
+		return err
+	}
 	rowsAffected, _ := res.RowsAffected()
 	if rowsAffected == 0 {
-		if _, err := db.db.Exec("INSERT INTO check_configs (project_id, application_id, configs) VALUES ($1, $2, $3)", projectId, appIdStr, string(data)); err != nil {
+		if _, err := tx.Exec("INSERT INTO check_configs (project_id, application_id, configs) VALUES ($1, $2, $3)", projectId, appIdStr, string(data)); err != nil {
 			return err
 		}
 	}
-	return err
+	var new string
+	if _, ok := cs[checkId]; ok {
+		new = string(c)
+	}
+	if err = addConfigChange(tx, projectId, ConfigScopeCheckConfig, appId, checkId, "", old, new); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
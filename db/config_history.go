@@ -0,0 +1,217 @@
+package db
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"k8s.io/klog"
+)
+
+const (
+	ConfigHistoryLastN = 100
+
+	settingsIntegrationsField = "integrations"
+)
+
+type ConfigScope string
+
+const (
+	ConfigScopeCheckConfig     ConfigScope = "check_config"
+	ConfigScopeProjectSettings ConfigScope = "project_settings"
+)
+
+type ConfigChange struct {
+	Version       int
+	Time          timeseries.Time
+	Scope         ConfigScope
+	ApplicationId model.ApplicationId
+	CheckId       model.CheckId
+	Author        string
+	Old           string
+	New           string
+}
+
+type ConfigHistory struct{}
+
+func (h *ConfigHistory) Migrate(m *Migrator) error {
+	return m.Exec(`
+	CREATE TABLE IF NOT EXISTS config_history (
+		project_id TEXT NOT NULL REFERENCES project(id),
+		version INT NOT NULL,
+		time INT NOT NULL,
+		scope TEXT NOT NULL,
+		application_id TEXT NOT NULL,
+		check_id TEXT NOT NULL DEFAULT '',
+		author TEXT NOT NULL DEFAULT '',
+		old TEXT,
+		new TEXT,
+		PRIMARY KEY (project_id, version)
+	)`)
+}
+
+func addConfigChange(tx *sql.Tx, projectId ProjectId, scope ConfigScope, appId model.ApplicationId, checkId model.CheckId, author, old, new string) error {
+	if old == new {
+		return nil
+	}
+	var version sql.NullInt64
+	if err := tx.QueryRow("SELECT max(version) FROM config_history WHERE project_id = $1", projectId).Scan(&version); err != nil {
+		return err
+	}
+	_, err := tx.Exec(
+		"INSERT INTO config_history (project_id, version, time, scope, application_id, check_id, author, old, new) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		projectId, version.Int64+1, timeseries.Now(), scope, appId.String(), checkId, author, sql.NullString{String: old, Valid: old != ""}, sql.NullString{String: new, Valid: new != ""},
+	)
+	return err
+}
+
+// settingsHistoryValue returns the project settings to be stored in the history.
+// Integrations are left out since they contain credentials.
+func settingsHistoryValue(settings string) (string, error) {
+	if settings == "" {
+		return "", nil
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(settings), &fields); err != nil {
+		return "", err
+	}
+	delete(fields, settingsIntegrationsField)
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (db *DB) GetConfigHistory(projectId ProjectId, scope ConfigScope, appId model.ApplicationId, checkId model.CheckId) ([]ConfigChange, error) {
+	q := "SELECT version, time, scope, application_id, check_id, author, old, new FROM config_history WHERE project_id = $1"
+	args := []any{projectId}
+	if scope != "" {
+		args = append(args, scope)
+		q += fmt.Sprintf(" AND scope = $%d", len(args))
+	}
+	if !appId.IsZero() {
+		args = append(args, appId.String())
+		q += fmt.Sprintf(" AND application_id = $%d", len(args))
+	}
+	if checkId != "" {
+		args = append(args, checkId)
+		q += fmt.Sprintf(" AND check_id = $%d", len(args))
+	}
+	q += fmt.Sprintf(" ORDER BY version DESC LIMIT %d", ConfigHistoryLastN)
+	rows, err := db.db.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var res []ConfigChange
+	for rows.Next() {
+		var c ConfigChange
+		var appIdStr string
+		var old, new sql.NullString
+		if err := rows.Scan(&c.Version, &c.Time, &c.Scope, &appIdStr, &c.CheckId, &c.Author, &old, &new); err != nil {
+			return nil, err
+		}
+		if c.ApplicationId, err = model.NewApplicationIdFromString(appIdStr); err != nil {
+			klog.Warningln(err)
+			continue
+		}
+		c.Old, c.New = old.String, new.String
+		res = append(res, c)
+	}
+	return res, nil
+}
+
+func (db *DB) RollbackConfigChange(projectId ProjectId, version int) error {
+	var c ConfigChange
+	var appIdStr string
+	var old, new sql.NullString
+	err := db.db.QueryRow(
+		"SELECT scope, application_id, check_id, old, new FROM config_history WHERE project_id = $1 AND version = $2",
+		projectId, version).Scan(&c.Scope, &appIdStr, &c.CheckId, &old, &new)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+	switch c.Scope {
+	case ConfigScopeCheckConfig:
+		appId, err := model.NewApplicationIdFromString(appIdStr)
+		if err != nil {
+			return err
+		}
+		var cfg json.RawMessage
+		if old.Valid {
+			cfg = json.RawMessage(old.String)
+		}
+		return db.SaveCheckConfig(projectId, appId, c.CheckId, cfg)
+	case ConfigScopeProjectSettings:
+		p, err := db.GetProject(projectId)
+		if err != nil {
+			return err
+		}
+		settings, err := rollbackSettings(p.Settings, old.String, new.String)
+		if err != nil {
+			return err
+		}
+		p.Settings = *settings
+		return db.saveProjectSettings(p)
+	}
+	return fmt.Errorf("unknown config scope: %s", c.Scope)
+}
+
+// rollbackSettings reverts only the top-level fields changed in the given version, keeping the current integrations.
+func rollbackSettings(current Settings, old, new string) (*Settings, error) {
+	data, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	o, n := map[string]json.RawMessage{}, map[string]json.RawMessage{}
+	if old != "" {
+		if err := json.Unmarshal([]byte(old), &o); err != nil {
+			return nil, err
+		}
+	}
+	if new != "" {
+		if err := json.Unmarshal([]byte(new), &n); err != nil {
+			return nil, err
+		}
+	}
+	changed := map[string]bool{}
+	for k, v := range o {
+		if !bytes.Equal(v, n[k]) {
+			changed[k] = true
+		}
+	}
+	for k := range n {
+		if _, ok := o[k]; !ok {
+			changed[k] = true
+		}
+	}
+	delete(changed, settingsIntegrationsField)
+	for k := range changed {
+		if v, ok := o[k]; ok {
+			fields[k] = v
+		} else {
+			delete(fields, k)
+		}
+	}
+	if data, err = json.Marshal(fields); err != nil {
+		return nil, err
+	}
+	var res Settings
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestConfigHistoryIntegrations(t *testing.T) {
+	db, projectId := newTestProject(t)
+	p, err := db.GetProject(projectId)
+	require.NoError(t, err)
+
+	p.Settings.Integrations.Slack = &IntegrationSlack{Token: "xoxb-secret", DefaultChannel: "ops", Enabled: true}
+	require.NoError(t, db.SaveProjectIntegration(p, IntegrationTypeSlack))
+	require.NoError(t, db.SaveEscalationPolicy(projectId, &EscalationPolicy{RenotifyInterval: timeseries.Hour}))
+
+	changes, err := db.GetConfigHistory(projectId, ConfigScopeProjectSettings, model.ApplicationIdZero, "")
+	require.NoError(t, err)
+	require.NotEmpty(t, changes)
+	for _, c := range changes {
+		assert.NotContains(t, c.Old, "xoxb-secret")
+		assert.NotContains(t, c.New, "xoxb-secret")
+		assert.NotContains(t, c.New, `"integrations"`)
+	}
+}
+
+func TestRollbackProjectSettings(t *testing.T) {
+	db, projectId := newTestProject(t)
+	p, err := db.GetProject(projectId)
+	require.NoError(t, err)
+
+	require.NoError(t, db.SaveEscalationPolicy(projectId, &EscalationPolicy{RenotifyInterval: timeseries.Hour}))
+	require.NoError(t, db.SaveCustomChecks(projectId, []model.CustomCheck{{Id: "c1", Title: "c1", Query: "up"}}))
+	p.Settings.Integrations.Slack = &IntegrationSlack{Token: "xoxb-secret", DefaultChannel: "ops", Enabled: true}
+	p.Settings.EscalationPolicy = &EscalationPolicy{RenotifyInterval: timeseries.Hour}
+	p.Settings.CustomChecks = []model.CustomCheck{{Id: "c1", Title: "c1", Query: "up"}}
+	require.NoError(t, db.SaveProjectIntegration(p, IntegrationTypeSlack))
+	require.NoError(t, db.SaveEscalationPolicy(projectId, &EscalationPolicy{RenotifyInterval: 2 * timeseries.Hour}))
+
+	changes, err := db.GetConfigHistory(projectId, ConfigScopeProjectSettings, model.ApplicationIdZero, "")
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	first := changes[len(changes)-1]
+
+	require.NoError(t, db.RollbackConfigChange(projectId, first.Version))
+	p, err = db.GetProject(projectId)
+	require.NoError(t, err)
+	assert.Nil(t, p.Settings.EscalationPolicy)
+	assert.Len(t, p.Settings.CustomChecks, 1)
+	require.NotNil(t, p.Settings.Integrations.Slack)
+	assert.Equal(t, "xoxb-secret", p.Settings.Integrations.Slack.Token)
+
+	changes, err = db.GetConfigHistory(projectId, ConfigScopeProjectSettings, model.ApplicationIdZero, "")
+	require.NoError(t, err)
+	assert.Len(t, changes, 4)
+}
+
+func TestCheckConfigHistory(t *testing.T) {
+	db, projectId := newTestProject(t)
+	appId := model.NewApplicationId("default", model.ApplicationKindDeployment, "catalog")
+
+	require.NoError(t, db.SaveCheckConfig(projectId, appId, model.Checks.CPUNode.Id, model.CheckConfigSimple{Threshold: 70}))
+	require.NoError(t, db.SaveCheckConfig(projectId, appId, model.Checks.CPUNode.Id, model.CheckConfigSimple{Threshold: 70}))
+	require.NoError(t, db.SaveCheckConfig(projectId, appId, model.Checks.CPUNode.Id, model.CheckConfigSimple{Threshold: 90}))
+
+	changes, err := db.GetConfigHistory(projectId, ConfigScopeCheckConfig, appId, model.Checks.CPUNode.Id)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, 2, changes[0].Version)
+	assert.Equal(t, 1, changes[1].Version)
+	assert.Equal(t, "", changes[1].Old)
+
+	require.NoError(t, db.RollbackConfigChange(projectId, 2))
+	configs, err := db.GetCheckConfigs(projectId)
+	require.NoError(t, err)
+	assert.Equal(t, float32(70), configs.GetSimple(model.Checks.CPUNode.Id, appId, "").Threshold)
+
+	require.NoError(t, db.RollbackConfigChange(projectId, 1))
+	configs, err = db.GetCheckConfigs(projectId)
+	require.NoError(t, err)
+	assert.Equal(t, model.Checks.CPUNode.DefaultThreshold, configs.GetSimple(model.Checks.CPUNode.Id, appId, "").Threshold)
+
+	assert.ErrorIs(t, db.RollbackConfigChange(projectId, 100), ErrNotFound)
+}
@@ -50,8 +50,10 @@ func Open(dataDir string, pgConnString string) (*DB, error) {
 	err = NewMigrator(typ, db).Migrate(
 		&Project{},
 		&CheckConfigs{},
+		&ConfigHistory{},
 		&Incident{},
 		&IncidentNotification{},
+		&IncidentCheckTransition{},
 		&ApplicationDeployment{},
 		&ApplicationSettings{},
 	)
@@ -123,7 +125,7 @@ func (m *Migrator) Exec(query string, args ...any) error {
 func (m *Migrator) AddColumnIfNotExists(table, column, dataType string) error {
 	switch m.typ {
 	case TypeSqlite:
-		rows, err := m.db.Query("SELECT name FROM pragma_table_info('project');")
+		rows, err := m.db.Query("SELECT name FROM pragma_table_info($1);", table)
 		if err != nil {
 			return nil
 		}
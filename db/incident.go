@@ -3,25 +3,34 @@ package db
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/coroot/coroot/model"
 	"github.com/coroot/coroot/timeseries"
 	"github.com/coroot/coroot/utils"
 	"k8s.io/klog"
+	"strings"
 )
 
 type Incident struct {
-	Key        string
-	OpenedAt   timeseries.Time
-	ResolvedAt timeseries.Time
-	Severity   model.Status
+	ApplicationId  model.ApplicationId
+	CheckId        model.CheckId
+	Key            string
+	OpenedAt       timeseries.Time
+	ResolvedAt     timeseries.Time
+	AcknowledgedAt timeseries.Time
+	Severity       model.Status
 }
 
 func (i *Incident) Resolved() bool {
 	return !i.ResolvedAt.IsZero()
 }
 
+func (i *Incident) Acknowledged() bool {
+	return !i.AcknowledgedAt.IsZero()
+}
+
 func (i *Incident) Migrate(m *Migrator) error {
-	return m.Exec(`
+	err := m.Exec(`
 	CREATE TABLE IF NOT EXISTS incident (
 		project_id TEXT NOT NULL REFERENCES project(id),
 		application_id TEXT NOT NULL,
@@ -33,8 +42,26 @@ func (i *Incident) Migrate(m *Migrator) error {
 	);
 	CREATE UNIQUE INDEX IF NOT EXISTS incident_key ON incident (project_id, key);
 `)
+	if err != nil {
+		return err
+	}
+	if err := m.AddColumnIfNotExists("incident", "acknowledged_at", "INT NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := m.AddColumnIfNotExists("incident", "check_id", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	return m.AddColumnIfNotExists("incident", "resolving_since", "INT NOT NULL DEFAULT 0")
 }
 
+type IncidentNotificationReason string
+
+const (
+	IncidentNotificationReasonStatusChange IncidentNotificationReason = ""
+	IncidentNotificationReasonReminder     IncidentNotificationReason = "reminder"
+	IncidentNotificationReasonEscalation   IncidentNotificationReason = "escalation"
+)
+
 type IncidentNotification struct {
 	ProjectId     ProjectId
 	ApplicationId model.ApplicationId
@@ -44,11 +71,17 @@ type IncidentNotification struct {
 	Timestamp     timeseries.Time
 	SentAt        timeseries.Time
 	ExternalKey   string
+	Reason        IncidentNotificationReason
 	Details       *IncidentNotificationDetails
+
+	Attempts    int
+	LastError   string
+	RetriedAt   timeseries.Time
+	DiscardedAt timeseries.Time
 }
 
 func (n *IncidentNotification) Migrate(m *Migrator) error {
-	return m.Exec(`
+	err := m.Exec(`
 	CREATE TABLE IF NOT EXISTS incident_notification (
 		project_id TEXT NOT NULL REFERENCES project(id),
 		application_id TEXT NOT NULL,
@@ -61,10 +94,28 @@ func (n *IncidentNotification) Migrate(m *Migrator) error {
 		details TEXT
 	);
 `)
+	if err != nil {
+		return err
+	}
+	columns := [][2]string{
+		{"reason", "TEXT NOT NULL DEFAULT ''"},
+		{"attempts", "INT NOT NULL DEFAULT 0"},
+		{"last_error", "TEXT NOT NULL DEFAULT ''"},
+		{"retried_at", "INT NOT NULL DEFAULT 0"},
+		{"discarded_at", "INT NOT NULL DEFAULT 0"},
+	}
+	for _, c := range columns {
+		if err := m.AddColumnIfNotExists("incident_notification", c[0], c[1]); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 type IncidentNotificationDetails struct {
 	Reports []IncidentNotificationDetailsReport `json:"reports"`
+	Hints   []model.RootCauseHint               `json:"hints,omitempty"`
+	Check   string                              `json:"check,omitempty"`
 }
 
 type IncidentNotificationDetailsReport struct {
@@ -76,14 +127,17 @@ type IncidentNotificationDetailsReport struct {
 func (db *DB) GetIncidentByKey(projectId ProjectId, key string) (*Incident, error) {
 	i := &Incident{Key: key}
 	err := db.db.QueryRow(
-		"SELECT opened_at, resolved_at, severity FROM incident WHERE project_id = $1 AND key = $2 LIMIT 1",
-		projectId, key).Scan(&i.OpenedAt, &i.ResolvedAt, &i.Severity)
+		"SELECT application_id, check_id, opened_at, resolved_at, acknowledged_at, severity FROM incident WHERE project_id = $1 AND key = $2 LIMIT 1",
+		projectId, key).Scan(&i.ApplicationId, &i.CheckId, &i.OpenedAt, &i.ResolvedAt, &i.AcknowledgedAt, &i.Severity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
 	return i, err
 }
 
 func (db *DB) GetIncidentsByApp(projectId ProjectId, appId model.ApplicationId, from, to timeseries.Time) ([]Incident, error) {
 	rows, err := db.db.Query(
-		"SELECT key, opened_at, resolved_at, severity FROM incident WHERE project_id = $1 AND application_id = $2 AND opened_at <= $3 AND (resolved_at = 0 OR resolved_at >= $4)",
+		"SELECT key, check_id, opened_at, resolved_at, acknowledged_at, severity FROM incident WHERE project_id = $1 AND application_id = $2 AND opened_at <= $3 AND (resolved_at = 0 OR resolved_at >= $4)",
 		projectId, appId.String(), to, from)
 	if err != nil {
 		return nil, err
@@ -92,9 +146,30 @@ func (db *DB) GetIncidentsByApp(projectId ProjectId, appId model.ApplicationId,
 		_ = rows.Close()
 	}()
 	var res []Incident
+	i := Incident{ApplicationId: appId}
+	for rows.Next() {
+		if err := rows.Scan(&i.Key, &i.CheckId, &i.OpenedAt, &i.ResolvedAt, &i.AcknowledgedAt, &i.Severity); err != nil {
+			return nil, err
+		}
+		res = append(res, i)
+	}
+	return res, err
+}
+
+func (db *DB) GetOpenIncidents(projectId ProjectId) ([]Incident, error) {
+	rows, err := db.db.Query(
+		"SELECT application_id, check_id, key, opened_at, resolved_at, acknowledged_at, severity FROM incident WHERE project_id = $1 AND resolved_at = 0",
+		projectId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var res []Incident
 	var i Incident
 	for rows.Next() {
-		if err := rows.Scan(&i.Key, &i.OpenedAt, &i.ResolvedAt, &i.Severity); err != nil {
+		if err := rows.Scan(&i.ApplicationId, &i.CheckId, &i.Key, &i.OpenedAt, &i.ResolvedAt, &i.AcknowledgedAt, &i.Severity); err != nil {
 			return nil, err
 		}
 		res = append(res, i)
@@ -102,40 +177,127 @@ func (db *DB) GetIncidentsByApp(projectId ProjectId, appId model.ApplicationId,
 	return res, err
 }
 
-func (db *DB) CreateOrUpdateIncident(projectId ProjectId, appId model.ApplicationId, now timeseries.Time, severity model.Status) (*Incident, error) {
+type IncidentFilter struct {
+	From          timeseries.Time
+	To            timeseries.Time
+	ApplicationId model.ApplicationId
+	Severity      model.Status
+	Resolved      *bool
+	Limit         int
+	Offset        int
+}
+
+func (db *DB) GetIncidents(projectId ProjectId, filter IncidentFilter) ([]Incident, int, error) {
+	where := []string{"project_id = $1", "opened_at <= $2", "(resolved_at = 0 OR resolved_at >= $3)"}
+	args := []any{projectId, filter.To, filter.From}
+	if !filter.ApplicationId.IsZero() {
+		args = append(args, filter.ApplicationId.String())
+		where = append(where, fmt.Sprintf("application_id = $%d", len(args)))
+	}
+	if filter.Severity > model.OK {
+		args = append(args, filter.Severity)
+		where = append(where, fmt.Sprintf("severity = $%d", len(args)))
+	}
+	if filter.Resolved != nil {
+		if *filter.Resolved {
+			where = append(where, "resolved_at > 0")
+		} else {
+			where = append(where, "resolved_at = 0")
+		}
+	}
+	cond := strings.Join(where, " AND ")
+
+	var total int
+	if err := db.db.QueryRow("SELECT count(*) FROM incident WHERE "+cond, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	rows, err := db.db.Query(
+		fmt.Sprintf("SELECT application_id, check_id, key, opened_at, resolved_at, acknowledged_at, severity FROM incident WHERE %s ORDER BY opened_at DESC LIMIT $%d OFFSET $%d", cond, len(args)-1, len(args)),
+		args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var res []Incident
+	var i Incident
+	for rows.Next() {
+		if err := rows.Scan(&i.ApplicationId, &i.CheckId, &i.Key, &i.OpenedAt, &i.ResolvedAt, &i.AcknowledgedAt, &i.Severity); err != nil {
+			return nil, 0, err
+		}
+		res = append(res, i)
+	}
+	return res, total, rows.Err()
+}
+
+func (db *DB) AcknowledgeIncident(projectId ProjectId, key string, now timeseries.Time) error {
+	res, err := db.db.Exec("UPDATE incident SET acknowledged_at = $1 WHERE project_id = $2 AND key = $3", now, projectId, key)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (db *DB) CreateOrUpdateIncident(projectId ProjectId, appId model.ApplicationId, checkId model.CheckId, now timeseries.Time, severity model.Status, policy *NotificationPolicy) (*Incident, error) {
 	appIdStr := appId.String()
-	var last Incident
+	last := Incident{ApplicationId: appId, CheckId: checkId}
+	var resolvingSince timeseries.Time
 	err := db.db.QueryRow(
-		"SELECT key, opened_at, resolved_at, severity FROM incident WHERE project_id = $1 AND application_id = $2 ORDER BY opened_at DESC LIMIT 1",
-		projectId, appIdStr).Scan(&last.Key, &last.OpenedAt, &last.ResolvedAt, &last.Severity)
+		"SELECT key, opened_at, resolved_at, acknowledged_at, severity, resolving_since FROM incident WHERE project_id = $1 AND application_id = $2 AND check_id = $3 ORDER BY opened_at DESC LIMIT 1",
+		projectId, appIdStr, checkId).Scan(&last.Key, &last.OpenedAt, &last.ResolvedAt, &last.AcknowledgedAt, &last.Severity, &resolvingSince)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return nil, err
 	}
 
 	if last.OpenedAt.IsZero() || last.Resolved() {
 		if severity > model.OK { // open
-			i := Incident{Key: utils.NanoId(8), OpenedAt: now, Severity: severity}
+			i := Incident{ApplicationId: appId, CheckId: checkId, Key: utils.NanoId(8), OpenedAt: now, Severity: severity}
 			_, err := db.db.Exec(
-				"INSERT INTO incident (project_id, application_id, key, opened_at, severity) VALUES ($1, $2, $3, $4, $5)",
-				projectId, appIdStr, i.Key, i.OpenedAt, i.Severity)
+				"INSERT INTO incident (project_id, application_id, check_id, key, opened_at, severity) VALUES ($1, $2, $3, $4, $5, $6)",
+				projectId, appIdStr, i.CheckId, i.Key, i.OpenedAt, i.Severity)
 			return &i, err
 		}
 		return nil, nil
 	}
 
 	if severity == model.OK { // close
-		last.ResolvedAt = now
+		if policy != nil && (policy.MinOpenDuration > 0 || policy.ResolveCooldown > 0) {
+			if resolvingSince.IsZero() {
+				resolvingSince = now
+				if _, err := db.db.Exec("UPDATE incident SET resolving_since = $1 WHERE project_id = $2 AND key = $3", resolvingSince, projectId, last.Key); err != nil {
+					return nil, err
+				}
+			}
+			if now.Sub(last.OpenedAt) < policy.MinOpenDuration || now.Sub(resolvingSince) < policy.ResolveCooldown {
+				return nil, nil
+			}
+			last.ResolvedAt = resolvingSince
+		} else {
+			last.ResolvedAt = now
+		}
 		_, err := db.db.Exec(
-			"UPDATE incident SET resolved_at = $1 WHERE project_id = $2 AND application_id = $3 AND opened_at = $4",
-			last.ResolvedAt, projectId, appIdStr, last.OpenedAt)
+			"UPDATE incident SET resolved_at = $1 WHERE project_id = $2 AND key = $3",
+			last.ResolvedAt, projectId, last.Key)
 		return &last, err
 	}
 
+	if !resolvingSince.IsZero() { // the incident is still active, cancel the pending resolve
+		if _, err := db.db.Exec("UPDATE incident SET resolving_since = 0 WHERE project_id = $1 AND key = $2", projectId, last.Key); err != nil {
+			return nil, err
+		}
+	}
+
 	if severity != last.Severity { // update severity
 		last.Severity = severity
 		_, err := db.db.Exec(
-			"UPDATE incident SET severity = $1 WHERE project_id = $2 AND application_id = $3 AND opened_at = $4",
-			last.Severity, projectId, appIdStr, last.OpenedAt)
+			"UPDATE incident SET severity = $1 WHERE project_id = $2 AND key = $3",
+			last.Severity, projectId, last.Key)
 		return &last, err
 	}
 
@@ -149,8 +311,8 @@ func (db *DB) PutIncidentNotification(n IncidentNotification) {
 		return
 	}
 	_, err = db.db.Exec(
-		"INSERT INTO incident_notification (project_id, application_id, incident_key, status, destination, timestamp, external_key, details) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
-		n.ProjectId, n.ApplicationId, n.IncidentKey, n.Status, n.Destination, n.Timestamp, n.ExternalKey, details,
+		"INSERT INTO incident_notification (project_id, application_id, incident_key, status, destination, timestamp, external_key, reason, details) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		n.ProjectId, n.ApplicationId, n.IncidentKey, n.Status, n.Destination, n.Timestamp, n.ExternalKey, n.Reason, details,
 	)
 	if err != nil {
 		klog.Errorln(err)
@@ -159,50 +321,130 @@ func (db *DB) PutIncidentNotification(n IncidentNotification) {
 
 func (db *DB) UpdateIncidentNotification(n IncidentNotification) error {
 	_, err := db.db.Exec(
-		"UPDATE incident_notification SET sent_at = $1, external_key = $2 WHERE project_id = $3 AND application_id = $4 AND incident_key = $5 AND timestamp = $6 AND destination = $7",
+		"UPDATE incident_notification SET sent_at = $1, external_key = $2, attempts = attempts + 1 WHERE project_id = $3 AND application_id = $4 AND incident_key = $5 AND timestamp = $6 AND destination = $7",
 		n.SentAt, n.ExternalKey, n.ProjectId, n.ApplicationId, n.IncidentKey, n.Timestamp, n.Destination,
 	)
 	return err
 }
 
+func (db *DB) UpdateIncidentNotificationError(n IncidentNotification, sendErr error) error {
+	_, err := db.db.Exec(
+		"UPDATE incident_notification SET attempts = attempts + 1, last_error = $1 WHERE project_id = $2 AND application_id = $3 AND incident_key = $4 AND timestamp = $5 AND destination = $6",
+		sendErr.Error(), n.ProjectId, n.ApplicationId, n.IncidentKey, n.Timestamp, n.Destination,
+	)
+	return err
+}
+
 func (db *DB) GetNotSentIncidentNotifications(from timeseries.Time) ([]IncidentNotification, error) {
-	rows, err := db.db.Query(`
-		SELECT project_id, application_id, incident_key, status, destination, timestamp, external_key, details 
+	return db.getIncidentNotifications(`
+		SELECT project_id, application_id, incident_key, status, destination, timestamp, sent_at, external_key, reason, details, attempts, last_error, retried_at, discarded_at 
 		FROM incident_notification 
-		WHERE timestamp >= $1 AND sent_at = 0 
+		WHERE (timestamp >= $1 OR retried_at >= $1) AND sent_at = 0 AND discarded_at = 0 
 		ORDER BY project_id, application_id, incident_key, timestamp
 	`, from)
+}
+
+func (db *DB) GetDeadLetterIncidentNotifications(projectId ProjectId, before timeseries.Time) ([]IncidentNotification, error) {
+	return db.getIncidentNotifications(`
+		SELECT project_id, application_id, incident_key, status, destination, timestamp, sent_at, external_key, reason, details, attempts, last_error, retried_at, discarded_at 
+		FROM incident_notification 
+		WHERE project_id = $1 AND timestamp < $2 AND retried_at < $2 AND sent_at = 0 AND discarded_at = 0 
+		ORDER BY timestamp DESC
+	`, projectId, before)
+}
+
+func (db *DB) GetDeadLetterIncidentNotificationsStat(before timeseries.Time) (map[IntegrationType]int, error) {
+	rows, err := db.db.Query(
+		"SELECT destination, count(*) FROM incident_notification WHERE timestamp < $1 AND retried_at < $1 AND sent_at = 0 AND discarded_at = 0 GROUP BY destination",
+		before)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
 		_ = rows.Close()
 	}()
-	var res []IncidentNotification
-	var details sql.NullString
+	res := map[IntegrationType]int{}
+	var destination IntegrationType
+	var count int
 	for rows.Next() {
-		var n IncidentNotification
-		if err := rows.Scan(&n.ProjectId, &n.ApplicationId, &n.IncidentKey, &n.Status, &n.Destination, &n.Timestamp, &n.ExternalKey, &details); err != nil {
+		if err := rows.Scan(&destination, &count); err != nil {
 			return nil, err
 		}
-		if details.String != "" {
-			if err := unmarshal(details.String, &n.Details); err != nil {
-				klog.Warningln(err)
-			}
-		}
-		res = append(res, n)
+		res[destination] = count
 	}
-	return res, nil
+	return res, rows.Err()
+}
+
+func (db *DB) RetryIncidentNotification(projectId ProjectId, incidentKey string, destination IntegrationType, timestamp, now timeseries.Time) error {
+	return db.updateDeadLetterIncidentNotification("retried_at", projectId, incidentKey, destination, timestamp, now)
+}
+
+func (db *DB) DiscardIncidentNotification(projectId ProjectId, incidentKey string, destination IntegrationType, timestamp, now timeseries.Time) error {
+	return db.updateDeadLetterIncidentNotification("discarded_at", projectId, incidentKey, destination, timestamp, now)
+}
+
+func (db *DB) updateDeadLetterIncidentNotification(column string, projectId ProjectId, incidentKey string, destination IntegrationType, timestamp, now timeseries.Time) error {
+	res, err := db.db.Exec(
+		"UPDATE incident_notification SET "+column+" = $1 WHERE project_id = $2 AND incident_key = $3 AND destination = $4 AND timestamp = $5 AND sent_at = 0",
+		now, projectId, incidentKey, destination, timestamp)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
 func (db *DB) GetPreviousIncidentNotifications(n IncidentNotification) ([]IncidentNotification, error) {
-	rows, err := db.db.Query(`
-		SELECT project_id, application_id, incident_key, status, destination, timestamp, external_key, details 
+	return db.getIncidentNotifications(`
+		SELECT project_id, application_id, incident_key, status, destination, timestamp, sent_at, external_key, reason, details, attempts, last_error, retried_at, discarded_at 
 		FROM incident_notification 
 		WHERE project_id = $1 AND application_id = $2 AND incident_key = $3 AND destination = $4 AND timestamp < $5 
 		ORDER BY timestamp
 	`, n.ProjectId, n.ApplicationId, n.IncidentKey, n.Destination, n.Timestamp,
 	)
+}
+
+func (db *DB) GetIncidentNotifications(projectId ProjectId, incidentKey string) ([]IncidentNotification, error) {
+	return db.getIncidentNotifications(`
+		SELECT project_id, application_id, incident_key, status, destination, timestamp, sent_at, external_key, reason, details, attempts, last_error, retried_at, discarded_at 
+		FROM incident_notification 
+		WHERE project_id = $1 AND incident_key = $2 
+		ORDER BY timestamp
+	`, projectId, incidentKey,
+	)
+}
+
+func (db *DB) GetIncidentsNotifications(projectId ProjectId, incidentKeys []string) (map[string][]IncidentNotification, error) {
+	res := map[string][]IncidentNotification{}
+	if len(incidentKeys) == 0 {
+		return res, nil
+	}
+	args := []any{projectId}
+	placeholders := make([]string, 0, len(incidentKeys))
+	for _, k := range incidentKeys {
+		args = append(args, k)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+	notifications, err := db.getIncidentNotifications(`
+		SELECT project_id, application_id, incident_key, status, destination, timestamp, sent_at, external_key, reason, details, attempts, last_error, retried_at, discarded_at 
+		FROM incident_notification 
+		WHERE project_id = $1 AND incident_key IN (`+strings.Join(placeholders, ", ")+`) 
+		ORDER BY timestamp
+	`, args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range notifications {
+		res[n.IncidentKey] = append(res[n.IncidentKey], n)
+	}
+	return res, nil
+}
+
+func (db *DB) getIncidentNotifications(query string, args ...any) ([]IncidentNotification, error) {
+	rows, err := db.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -213,7 +455,7 @@ func (db *DB) GetPreviousIncidentNotifications(n IncidentNotification) ([]Incide
 	var details sql.NullString
 	for rows.Next() {
 		var n IncidentNotification
-		if err := rows.Scan(&n.ProjectId, &n.ApplicationId, &n.IncidentKey, &n.Status, &n.Destination, &n.Timestamp, &n.ExternalKey, &details); err != nil {
+		if err := rows.Scan(&n.ProjectId, &n.ApplicationId, &n.IncidentKey, &n.Status, &n.Destination, &n.Timestamp, &n.SentAt, &n.ExternalKey, &n.Reason, &details, &n.Attempts, &n.LastError, &n.RetriedAt, &n.DiscardedAt); err != nil {
 			return nil, err
 		}
 		if details.String != "" {
@@ -226,6 +468,14 @@ func (db *DB) GetPreviousIncidentNotifications(n IncidentNotification) ([]Incide
 	return res, nil
 }
 
+func (db *DB) GetLastIncidentNotificationSentAt(projectId ProjectId, destination IntegrationType) (timeseries.Time, error) {
+	var sentAt sql.NullInt64
+	err := db.db.QueryRow(
+		"SELECT max(sent_at) FROM incident_notification WHERE project_id = $1 AND destination = $2",
+		projectId, destination).Scan(&sentAt)
+	return timeseries.Time(sentAt.Int64), err
+}
+
 func (db *DB) GetSentIncidentNotificationsStat(from timeseries.Time) map[IntegrationType]int {
 	rows, err := db.db.Query("SELECT destination, count(*) FROM incident_notification WHERE timestamp >= $1 AND sent_at > 0 GROUP BY destination", from)
 	if err != nil {
@@ -247,3 +497,89 @@ func (db *DB) GetSentIncidentNotificationsStat(from timeseries.Time) map[Integra
 	}
 	return res
 }
+
+type IncidentCheckTransition struct {
+	Timestamp timeseries.Time
+	Report    model.AuditReportName
+	CheckId   model.CheckId
+	Title     string
+	Status    model.Status
+	Message   string
+}
+
+func (t *IncidentCheckTransition) Migrate(m *Migrator) error {
+	return m.Exec(`
+	CREATE TABLE IF NOT EXISTS incident_check_transition (
+		project_id TEXT NOT NULL REFERENCES project(id),
+		incident_key TEXT NOT NULL,
+		timestamp INT NOT NULL,
+		report TEXT NOT NULL,
+		check_id TEXT NOT NULL,
+		title TEXT NOT NULL DEFAULT '',
+		status INT NOT NULL,
+		message TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX IF NOT EXISTS incident_check_transition_incident ON incident_check_transition (project_id, incident_key);
+`)
+}
+
+func (db *DB) AddIncidentCheckTransitions(projectId ProjectId, incidentKey string, transitions []IncidentCheckTransition) error {
+	if len(transitions) == 0 {
+		return nil
+	}
+	tx, err := db.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	for _, t := range transitions {
+		_, err := tx.Exec(
+			"INSERT INTO incident_check_transition (project_id, incident_key, timestamp, report, check_id, title, status, message) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+			projectId, incidentKey, t.Timestamp, t.Report, t.CheckId, t.Title, t.Status, t.Message)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (db *DB) GetIncidentCheckTransitions(projectId ProjectId, incidentKey string) ([]IncidentCheckTransition, error) {
+	res, err := db.GetIncidentsCheckTransitions(projectId, []string{incidentKey})
+	if err != nil {
+		return nil, err
+	}
+	return res[incidentKey], nil
+}
+
+func (db *DB) GetIncidentsCheckTransitions(projectId ProjectId, incidentKeys []string) (map[string][]IncidentCheckTransition, error) {
+	res := map[string][]IncidentCheckTransition{}
+	if len(incidentKeys) == 0 {
+		return res, nil
+	}
+	args := []any{projectId}
+	placeholders := make([]string, 0, len(incidentKeys))
+	for _, k := range incidentKeys {
+		args = append(args, k)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+	rows, err := db.db.Query(
+		"SELECT incident_key, timestamp, report, check_id, title, status, message FROM incident_check_transition WHERE project_id = $1 AND incident_key IN ("+strings.Join(placeholders, ", ")+") ORDER BY timestamp",
+		args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+		var key string
+		var t IncidentCheckTransition
+		if err := rows.Scan(&key, &t.Timestamp, &t.Report, &t.CheckId, &t.Title, &t.Status, &t.Message); err != nil {
+			return nil, err
+		}
+		res[key] = append(res[key], t)
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,160 @@
+package db
+
+import (
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newTestProject(t *testing.T) (*DB, ProjectId) {
+	db, err := Open(t.TempDir(), "")
+	require.NoError(t, err)
+	id, err := db.SaveProject(Project{Name: "test"})
+	require.NoError(t, err)
+	return db, id
+}
+
+func TestGetIncidents(t *testing.T) {
+	db, projectId := newTestProject(t)
+	catalog := model.NewApplicationId("default", model.ApplicationKindDeployment, "catalog")
+	orders := model.NewApplicationId("default", model.ApplicationKindDeployment, "orders")
+
+	insert := func(appId model.ApplicationId, key string, openedAt, resolvedAt timeseries.Time, severity model.Status) {
+		_, err := db.db.Exec(
+			"INSERT INTO incident (project_id, application_id, check_id, key, opened_at, resolved_at, severity) VALUES ($1, $2, '', $3, $4, $5, $6)",
+			projectId, appId.String(), key, openedAt, resolvedAt, severity)
+		require.NoError(t, err)
+	}
+	insert(catalog, "c1", 100, 200, model.CRITICAL)
+	insert(catalog, "c2", 300, 0, model.WARNING)
+	insert(orders, "o1", 150, 250, model.WARNING)
+	insert(orders, "o2", 500, 0, model.CRITICAL)
+
+	yes, no := true, false
+	tests := []struct {
+		name   string
+		filter IncidentFilter
+		keys   []string
+		total  int
+	}{
+		{name: "all", filter: IncidentFilter{From: 0, To: 1000, Limit: 10}, keys: []string{"o2", "c2", "o1", "c1"}, total: 4},
+		{name: "time range", filter: IncidentFilter{From: 260, To: 400, Limit: 10}, keys: []string{"c2"}, total: 1},
+		{name: "application", filter: IncidentFilter{From: 0, To: 1000, ApplicationId: orders, Limit: 10}, keys: []string{"o2", "o1"}, total: 2},
+		{name: "severity", filter: IncidentFilter{From: 0, To: 1000, Severity: model.CRITICAL, Limit: 10}, keys: []string{"o2", "c1"}, total: 2},
+		{name: "resolved", filter: IncidentFilter{From: 0, To: 1000, Resolved: &yes, Limit: 10}, keys: []string{"o1", "c1"}, total: 2},
+		{name: "open", filter: IncidentFilter{From: 0, To: 1000, Resolved: &no, Limit: 10}, keys: []string{"o2", "c2"}, total: 2},
+		{name: "combined", filter: IncidentFilter{From: 0, To: 1000, ApplicationId: catalog, Severity: model.WARNING, Resolved: &no, Limit: 10}, keys: []string{"c2"}, total: 1},
+		{name: "pagination", filter: IncidentFilter{From: 0, To: 1000, Limit: 2, Offset: 1}, keys: []string{"c2", "o1"}, total: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incidents, total, err := db.GetIncidents(projectId, tt.filter)
+			require.NoError(t, err)
+			var keys []string
+			for _, i := range incidents {
+				keys = append(keys, i.Key)
+			}
+			assert.Equal(t, tt.keys, keys)
+			assert.Equal(t, tt.total, total)
+		})
+	}
+}
+
+func TestGetIncidentsNotifications(t *testing.T) {
+	db, projectId := newTestProject(t)
+	appId := model.NewApplicationId("default", model.ApplicationKindDeployment, "catalog")
+	for _, n := range []IncidentNotification{
+		{IncidentKey: "i1", Destination: IntegrationTypeSlack, Timestamp: 100, Status: model.CRITICAL},
+		{IncidentKey: "i1", Destination: IntegrationTypeSlack, Timestamp: 200, Status: model.OK},
+		{IncidentKey: "i2", Destination: IntegrationTypeTeams, Timestamp: 150, Status: model.WARNING},
+		{IncidentKey: "i3", Destination: IntegrationTypeTeams, Timestamp: 150, Status: model.WARNING},
+	} {
+		n.ProjectId, n.ApplicationId = projectId, appId
+		db.PutIncidentNotification(n)
+	}
+
+	res, err := db.GetIncidentsNotifications(projectId, []string{"i1", "i2", "i4"})
+	require.NoError(t, err)
+	assert.Len(t, res, 2)
+	require.Len(t, res["i1"], 2)
+	assert.Equal(t, model.CRITICAL, res["i1"][0].Status)
+	assert.Equal(t, model.OK, res["i1"][1].Status)
+	assert.Len(t, res["i2"], 1)
+
+	res, err = db.GetIncidentsNotifications(projectId, nil)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestCreateOrUpdateIncident(t *testing.T) {
+	appId := model.NewApplicationId("default", model.ApplicationKindDeployment, "catalog")
+	type step struct {
+		now            timeseries.Time
+		severity       model.Status
+		changed        bool
+		resolvedAt     timeseries.Time
+		resolvingSince timeseries.Time
+	}
+	tests := []struct {
+		name   string
+		policy *NotificationPolicy
+		steps  []step
+	}{
+		{
+			name: "no policy",
+			steps: []step{
+				{now: 1000, severity: model.OK},
+				{now: 1060, severity: model.CRITICAL, changed: true},
+				{now: 1120, severity: model.CRITICAL},
+				{now: 1180, severity: model.WARNING, changed: true},
+				{now: 1240, severity: model.OK, changed: true, resolvedAt: 1240},
+				{now: 1300, severity: model.OK},
+			},
+		},
+		{
+			name:   "min open duration and resolve cooldown",
+			policy: &NotificationPolicy{MinOpenDuration: 5 * timeseries.Minute, ResolveCooldown: 3 * timeseries.Minute},
+			steps: []step{
+				{now: 1000, severity: model.CRITICAL, changed: true},
+				{now: 1060, severity: model.CRITICAL},
+				{now: 1120, severity: model.OK, resolvingSince: 1120},
+				{now: 1180, severity: model.WARNING, changed: true},
+				{now: 1240, severity: model.OK, resolvingSince: 1240},
+				{now: 1300, severity: model.OK, resolvingSince: 1240},
+				{now: 1420, severity: model.OK, changed: true, resolvedAt: 1240, resolvingSince: 1240},
+				{now: 1480, severity: model.OK, resolvingSince: 1240},
+			},
+		},
+		{
+			name:   "flapping within cooldown",
+			policy: &NotificationPolicy{ResolveCooldown: 2 * timeseries.Minute},
+			steps: []step{
+				{now: 1000, severity: model.WARNING, changed: true},
+				{now: 1060, severity: model.OK, resolvingSince: 1060},
+				{now: 1120, severity: model.WARNING},
+				{now: 1180, severity: model.OK, resolvingSince: 1180},
+				{now: 1300, severity: model.OK, changed: true, resolvedAt: 1180, resolvingSince: 1180},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, projectId := newTestProject(t)
+			for _, s := range tt.steps {
+				incident, err := db.CreateOrUpdateIncident(projectId, appId, "", s.now, s.severity, tt.policy)
+				require.NoError(t, err)
+				assert.Equal(t, s.changed, incident != nil, "step %d", s.now)
+				if incident != nil {
+					assert.Equal(t, s.resolvedAt, incident.ResolvedAt, "step %d", s.now)
+				}
+				var resolvingSince timeseries.Time
+				err = db.db.QueryRow("SELECT resolving_since FROM incident WHERE project_id = $1 ORDER BY opened_at DESC LIMIT 1", projectId).Scan(&resolvingSince)
+				if err == nil {
+					assert.Equal(t, s.resolvingSince, resolvingSince, "step %d", s.now)
+				}
+			}
+		})
+	}
+}
@@ -14,6 +14,8 @@ const (
 	IntegrationTypePagerduty  IntegrationType = "pagerduty"
 	IntegrationTypeTeams      IntegrationType = "teams"
 	IntegrationTypeOpsgenie   IntegrationType = "opsgenie"
+	IntegrationTypeTelegram   IntegrationType = "telegram"
+	IntegrationTypeMattermost IntegrationType = "mattermost"
 )
 
 type Integrations struct {
@@ -24,6 +26,9 @@ type Integrations struct {
 	Teams     *IntegrationTeams     `json:"teams,omitempty"`
 	Opsgenie  *IntegrationOpsgenie  `json:"opsgenie,omitempty"`
 
+	Telegram   *IntegrationTelegram   `json:"telegram,omitempty"`
+	Mattermost *IntegrationMattermost `json:"mattermost,omitempty"`
+
 	Pyroscope *IntegrationPyroscope `json:"pyroscope,omitempty"`
 }
 
@@ -56,6 +61,24 @@ func (integrations Integrations) GetInfo() []IntegrationInfo {
 	}
 	res = append(res, i)
 
+	i = IntegrationInfo{Type: IntegrationTypeMattermost, Title: "Mattermost"}
+	if cfg := integrations.Mattermost; cfg != nil {
+		i.Configured = true
+		i.Incidents = cfg.Incidents
+		i.Deployments = cfg.Deployments
+		i.Details = fmt.Sprintf("channel: %s", cfg.ChannelId)
+	}
+	res = append(res, i)
+
+	i = IntegrationInfo{Type: IntegrationTypeTelegram, Title: "Telegram"}
+	if cfg := integrations.Telegram; cfg != nil {
+		i.Configured = true
+		i.Incidents = cfg.Incidents
+		i.Deployments = cfg.Deployments
+		i.Details = fmt.Sprintf("chat: %s", cfg.ChatId)
+	}
+	res = append(res, i)
+
 	i = IntegrationInfo{Type: IntegrationTypePagerduty, Title: "Pagerduty"}
 	if cfg := integrations.Pagerduty; cfg != nil {
 		i.Configured = true
@@ -99,23 +122,56 @@ type IntegrationSlack struct {
 	Enabled        bool   `json:"enabled"` // deprecated: use Incidents and Deployments
 	Incidents      bool   `json:"incidents"`
 	Deployments    bool   `json:"deployments"`
+
+	Templates NotificationTemplates `json:"templates"`
 }
 
 type IntegrationTeams struct {
 	WebhookUrl  string `json:"webhook_url"`
 	Incidents   bool   `json:"incidents"`
 	Deployments bool   `json:"deployments"`
+
+	Templates NotificationTemplates `json:"templates"`
 }
 
 type IntegrationPagerduty struct {
 	IntegrationKey string `json:"integration_key"`
 	Incidents      bool   `json:"incidents"`
+
+	Templates NotificationTemplates `json:"templates"`
 }
 
 type IntegrationOpsgenie struct {
 	ApiKey     string `json:"api_key"`
 	EUInstance bool   `json:"eu_instance"`
 	Incidents  bool   `json:"incidents"`
+
+	Templates NotificationTemplates `json:"templates"`
+}
+
+type IntegrationTelegram struct {
+	BotToken    string `json:"bot_token"`
+	ChatId      string `json:"chat_id"`
+	Incidents   bool   `json:"incidents"`
+	Deployments bool   `json:"deployments"`
+
+	Templates NotificationTemplates `json:"templates"`
+}
+
+type IntegrationMattermost struct {
+	Url         string `json:"url"`
+	Token       string `json:"token"`
+	ChannelId   string `json:"channel_id"`
+	Incidents   bool   `json:"incidents"`
+	Deployments bool   `json:"deployments"`
+
+	Templates NotificationTemplates `json:"templates"`
+}
+
+type NotificationTemplates struct {
+	IncidentOpen      string `json:"incident_open"`
+	IncidentResolve   string `json:"incident_resolve"`
+	DeploymentSummary string `json:"deployment_summary"`
 }
 
 type BasicAuth struct {
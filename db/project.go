@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
 	"github.com/coroot/coroot/utils"
 	"strings"
 )
 
 const (
 	DefaultRefreshInterval = 30
+	MaxDigestInterval      = 30 * timeseries.Minute
 )
 
 type ProjectId string
@@ -28,12 +30,27 @@ type Settings struct {
 	ApplicationCategories       map[model.ApplicationCategory][]string                    `json:"application_categories"`
 	ApplicationCategorySettings map[model.ApplicationCategory]ApplicationCategorySettings `json:"application_category_settings"`
 	Integrations                Integrations                                              `json:"integrations"`
+	EscalationPolicy            *EscalationPolicy                                         `json:"escalation_policy,omitempty"`
+	NotificationPolicy          *NotificationPolicy                                       `json:"notification_policy,omitempty"`
+	CustomChecks                []model.CustomCheck                                       `json:"custom_checks,omitempty"`
 }
 
 type ApplicationCategorySettings struct {
 	NotifyOfDeployments bool `json:"notify_of_deployments"`
 }
 
+type EscalationPolicy struct {
+	RenotifyInterval timeseries.Duration `json:"renotify_interval"`
+	EscalateAfter    timeseries.Duration `json:"escalate_after"`
+	EscalateTo       IntegrationType     `json:"escalate_to"`
+}
+
+type NotificationPolicy struct {
+	MinOpenDuration timeseries.Duration `json:"min_open_duration"`
+	ResolveCooldown timeseries.Duration `json:"resolve_cooldown"`
+	DigestInterval  timeseries.Duration `json:"digest_interval"`
+}
+
 func (p *Project) Migrate(m *Migrator) error {
 	err := m.Exec(`
 	CREATE TABLE IF NOT EXISTS project (
@@ -172,12 +189,18 @@ func (db *DB) DeleteProject(id ProjectId) error {
 	defer func() {
 		_ = tx.Rollback()
 	}()
+	if _, err := tx.Exec("DELETE FROM config_history WHERE project_id = $1", id); err != nil {
+		return err
+	}
 	if _, err := tx.Exec("DELETE FROM check_configs WHERE project_id = $1", id); err != nil {
 		return err
 	}
 	if _, err := tx.Exec("DELETE FROM incident_notification WHERE project_id = $1", id); err != nil {
 		return err
 	}
+	if _, err := tx.Exec("DELETE FROM incident_check_transition WHERE project_id = $1", id); err != nil {
+		return err
+	}
 	if _, err := tx.Exec("DELETE FROM incident WHERE project_id = $1", id); err != nil {
 		return err
 	}
@@ -252,13 +275,64 @@ func (db *DB) SaveApplicationCategory(id ProjectId, category, newName model.Appl
 	return db.saveProjectSettings(p)
 }
 
+func (db *DB) SaveEscalationPolicy(id ProjectId, policy *EscalationPolicy) error {
+	p, err := db.GetProject(id)
+	if err != nil {
+		return err
+	}
+	p.Settings.EscalationPolicy = policy
+	return db.saveProjectSettings(p)
+}
+
+func (db *DB) SaveNotificationPolicy(id ProjectId, policy *NotificationPolicy) error {
+	p, err := db.GetProject(id)
+	if err != nil {
+		return err
+	}
+	p.Settings.NotificationPolicy = policy
+	return db.saveProjectSettings(p)
+}
+
+func (db *DB) SaveCustomChecks(id ProjectId, checks []model.CustomCheck) error {
+	p, err := db.GetProject(id)
+	if err != nil {
+		return err
+	}
+	p.Settings.CustomChecks = checks
+	return db.saveProjectSettings(p)
+}
+
 func (db *DB) saveProjectSettings(p *Project) error {
 	settings, err := json.Marshal(p.Settings)
 	if err != nil {
 		return err
 	}
-	_, err = db.db.Exec("UPDATE project SET settings = $1 WHERE id = $2", string(settings), p.Id)
-	return err
+	tx, err := db.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	var old sql.NullString
+	if err := tx.QueryRow("SELECT settings FROM project WHERE id = $1", p.Id).Scan(&old); err != nil {
+		return err
+	}
+	if _, err = tx.Exec("UPDATE project SET settings = $1 WHERE id = $2", string(settings), p.Id); err != nil {
+		return err
+	}
+	oldValue, err := settingsHistoryValue(old.String)
+	if err != nil {
+		return err
+	}
+	newValue, err := settingsHistoryValue(string(settings))
+	if err != nil {
+		return err
+	}
+	if err = addConfigChange(tx, p.Id, ConfigScopeProjectSettings, model.ApplicationIdZero, "", "", oldValue, newValue); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (db *DB) SaveProjectIntegration(p *Project, typ IntegrationType) error {
@@ -273,10 +347,5 @@ func (db *DB) SaveProjectIntegration(p *Project, typ IntegrationType) error {
 		_, err = db.db.Exec("UPDATE project SET prometheus = $1 WHERE id = $2", string(prometheus), p.Id)
 		return err
 	}
-	settings, err := json.Marshal(p.Settings)
-	if err != nil {
-		return err
-	}
-	_, err = db.db.Exec("UPDATE project SET settings = $1 WHERE id = $2", string(settings), p.Id)
-	return err
+	return db.saveProjectSettings(p)
 }
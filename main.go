@@ -110,11 +110,22 @@ func main() {
 	r.HandleFunc("/api/project/{project}/configs", a.Configs).Methods(http.MethodGet)
 	r.HandleFunc("/api/project/{project}/categories", a.Categories).Methods(http.MethodGet, http.MethodPost)
 	r.HandleFunc("/api/project/{project}/integrations", a.Integrations).Methods(http.MethodGet, http.MethodPut)
+	r.HandleFunc("/api/project/{project}/notification_template/preview", a.NotificationTemplatePreview).Methods(http.MethodPost)
 	r.HandleFunc("/api/project/{project}/integrations/{type}", a.Integration).Methods(http.MethodGet, http.MethodPut, http.MethodDelete, http.MethodPost)
+	r.HandleFunc("/api/project/{project}/escalation_policy", a.EscalationPolicy).Methods(http.MethodGet, http.MethodPost)
+	r.HandleFunc("/api/project/{project}/custom_checks", a.CustomChecks).Methods(http.MethodGet, http.MethodPost)
+	r.HandleFunc("/api/project/{project}/config_history", a.ConfigHistory).Methods(http.MethodGet, http.MethodPost)
+	r.HandleFunc("/api/project/{project}/notification_policy", a.NotificationPolicy).Methods(http.MethodGet, http.MethodPost)
+	r.HandleFunc("/api/project/{project}/notifications/dead_letters", a.DeadLetters).Methods(http.MethodGet, http.MethodPost)
+	r.HandleFunc("/api/project/{project}/incidents", a.Incidents).Methods(http.MethodGet)
+	r.HandleFunc("/api/project/{project}/slo/compliance", a.SLOCompliance).Methods(http.MethodGet)
+	r.HandleFunc("/api/project/{project}/incident/{incident}/timeline", a.IncidentTimeline).Methods(http.MethodGet)
+	r.HandleFunc("/api/project/{project}/incident/{incident}", a.Incident).Methods(http.MethodPost)
 	r.HandleFunc("/api/project/{project}/app/{app}", a.App).Methods(http.MethodGet)
 	r.HandleFunc("/api/project/{project}/app/{app}/check/{check}/config", a.Check).Methods(http.MethodGet, http.MethodPost)
 	r.HandleFunc("/api/project/{project}/app/{app}/profile/{profile}", a.Profile).Methods(http.MethodGet)
 	r.HandleFunc("/api/project/{project}/app/{app}/profile", a.Profile).Methods(http.MethodPost)
+	r.HandleFunc("/api/project/{project}/app/{app}/kafka/consumer_groups", a.KafkaConsumerGroups).Methods(http.MethodGet, http.MethodPost)
 	r.HandleFunc("/api/project/{project}/node/{node}", a.Node).Methods(http.MethodGet)
 	r.PathPrefix("/api/project/{project}/prom").HandlerFunc(a.Prom)
 
@@ -6,33 +6,43 @@ import (
 	"github.com/dustin/go-humanize/english"
 )
 
+const AlertRulesId CheckId = "SLOAlertRules"
+
 type AlertRule struct {
-	LongWindow        timeseries.Duration
-	ShortWindow       timeseries.Duration
-	BurnRateThreshold float32
-	Severity          Status
+	LongWindow        timeseries.Duration `json:"long_window"`
+	ShortWindow       timeseries.Duration `json:"short_window"`
+	BurnRateThreshold float32             `json:"burn_rate_threshold"`
+	Severity          Status              `json:"severity"`
+}
+
+func (r AlertRule) Valid() bool {
+	if r.ShortWindow <= 0 || r.LongWindow < r.ShortWindow || r.LongWindow > MaxAlertRuleWindowLimit {
+		return false
+	}
+	if r.BurnRateThreshold <= 0 {
+		return false
+	}
+	return r.Severity == WARNING || r.Severity == CRITICAL
 }
 
 var (
-	AlertRules = []AlertRule{
+	DefaultAlertRules = []AlertRule{
 		{LongWindow: timeseries.Hour, ShortWindow: 5 * timeseries.Minute, BurnRateThreshold: 14.4, Severity: CRITICAL},
 		{LongWindow: 6 * timeseries.Hour, ShortWindow: 30 * timeseries.Minute, BurnRateThreshold: 6, Severity: CRITICAL},
 		{LongWindow: timeseries.Day, ShortWindow: 2 * timeseries.Hour, BurnRateThreshold: 3, Severity: WARNING},
 		{LongWindow: 3 * timeseries.Day, ShortWindow: 6 * timeseries.Hour, BurnRateThreshold: 1, Severity: WARNING},
 	}
-	MaxAlertRuleWindow timeseries.Duration
+	MaxAlertRuleWindowLimit = 7 * timeseries.Day
 )
 
-func init() {
-	MaxAlertRuleWindow = timeseries.Hour
-	for _, r := range AlertRules {
-		if r.ShortWindow > r.LongWindow {
-			panic("invalid rule")
-		}
-		if r.LongWindow > MaxAlertRuleWindow {
-			MaxAlertRuleWindow = r.LongWindow
+func MaxAlertRuleWindow(rules []AlertRule) timeseries.Duration {
+	res := timeseries.Hour
+	for _, r := range rules {
+		if r.LongWindow > res {
+			res = r.LongWindow
 		}
 	}
+	return res
 }
 
 type BurnRate struct {
@@ -46,7 +56,7 @@ func (br BurnRate) FormatSLOStatus() string {
 	return fmt.Sprintf("error budget burn rate is %.1fx within %s", br.Value, english.Plural(hours, "hour", ""))
 }
 
-func CheckBurnRates(now timeseries.Time, bad, total *timeseries.TimeSeries, objectivePercentage float32) BurnRate {
+func CheckBurnRates(now timeseries.Time, bad, total *timeseries.TimeSeries, objectivePercentage float32, rules []AlertRule) BurnRate {
 	if bad.IsEmpty() || total.IsEmpty() {
 		return BurnRate{Severity: UNKNOWN}
 	}
@@ -63,7 +73,7 @@ func CheckBurnRates(now timeseries.Time, bad, total *timeseries.TimeSeries, obje
 	}
 
 	first := BurnRate{}
-	for _, r := range AlertRules {
+	for _, r := range rules {
 		from := now.Add(-r.LongWindow)
 		br := sumFrom(bad, from) / sumFrom(total, from) / objective
 		if timeseries.IsNaN(br) {
@@ -20,12 +20,20 @@ type Application struct {
 
 	LatencySLIs      []*LatencySLI
 	AvailabilitySLIs []*AvailabilitySLI
+	RouteSLIs        []*RouteSLI
+	DependencySLIs   []*DependencySLI
+
+	CustomChecks []*CustomCheckData
+
+	KafkaConsumerGroups []string
 
 	Events      []*ApplicationEvent
 	Deployments []*ApplicationDeployment
 
 	Status  Status
 	Reports []*AuditReport
+
+	RootCauseHints []*RootCauseHint
 }
 
 func NewApplication(id ApplicationId) *Application {
@@ -35,13 +43,35 @@ func NewApplication(id ApplicationId) *Application {
 
 func (app *Application) SLOStatus() Status {
 	for _, r := range app.Reports {
-		if r.Name == AuditReportSLO {
-			return r.Status
+		if r.Name != AuditReportSLO {
+			continue
 		}
+		status := UNKNOWN
+		for _, ch := range r.Checks {
+			if ch.Id != Checks.SLODependency.Id && ch.Status > status {
+				status = ch.Status
+			}
+		}
+		return status
 	}
 	return UNKNOWN
 }
 
+func (app *Application) DependencySLOStatus() Status {
+	status := UNKNOWN
+	for _, r := range app.Reports {
+		if r.Name != AuditReportSLO {
+			continue
+		}
+		for _, ch := range r.Checks {
+			if ch.Id == Checks.SLODependency.Id && ch.Status > status {
+				status = ch.Status
+			}
+		}
+	}
+	return status
+}
+
 func (app *Application) GetInstance(name, node string) *Instance {
 	for _, i := range app.Instances {
 		if i.Name != name {
@@ -133,6 +163,51 @@ func (app *Application) IsPostgres() bool {
 	return false
 }
 
+func (app *Application) IsMysql() bool {
+	for _, i := range app.Instances {
+		if i.Mysql != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (app *Application) IsMongodb() bool {
+	for _, i := range app.Instances {
+		if i.Mongodb != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (app *Application) IsKafka() bool {
+	for _, i := range app.Instances {
+		if i.Kafka != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (app *Application) IsElasticsearch() bool {
+	for _, i := range app.Instances {
+		if i.Elasticsearch != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (app *Application) IsRabbitmq() bool {
+	for _, i := range app.Instances {
+		if i.Rabbitmq != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (app *Application) IsJvm() bool {
 	for _, i := range app.Instances {
 		if i.Jvm != nil {
@@ -172,6 +247,16 @@ func (app *Application) InstrumentationStatus() map[ApplicationType]bool {
 			case ApplicationTypeRedis, ApplicationTypeKeyDB:
 				t = ApplicationTypeRedis
 				instanceInstrumented = i.Redis != nil
+			case ApplicationTypeMysql:
+				instanceInstrumented = i.Mysql != nil
+			case ApplicationTypeMongodb:
+				instanceInstrumented = i.Mongodb != nil
+			case ApplicationTypeKafka:
+				instanceInstrumented = app.IsKafka()
+			case ApplicationTypeElasticsearch:
+				instanceInstrumented = i.Elasticsearch != nil
+			case ApplicationTypeRabbitmq:
+				instanceInstrumented = app.IsRabbitmq()
 			default:
 				continue
 			}
@@ -193,6 +278,19 @@ func (app *Application) GetClientsConnections() map[ApplicationId][]*Connection
 	return res
 }
 
+func (app *Application) GetUpstreamConnections() map[ApplicationId][]*Connection {
+	res := map[ApplicationId][]*Connection{}
+	for _, i := range app.Instances {
+		for _, u := range i.Upstreams {
+			if u.RemoteInstance == nil || u.RemoteInstance.OwnerId == app.Id {
+				continue
+			}
+			res[u.RemoteInstance.OwnerId] = append(res[u.RemoteInstance.OwnerId], u)
+		}
+	}
+	return res
+}
+
 func (app *Application) AddReport(name AuditReportName, widgets ...*Widget) {
 	app.Reports = append(app.Reports, &AuditReport{Name: name, Widgets: widgets})
 }
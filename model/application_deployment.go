@@ -92,6 +92,14 @@ type ApplicationDeploymentNotifications struct {
 	Teams struct {
 		State ApplicationDeploymentState `json:"state"`
 	} `json:"teams"`
+	Mattermost struct {
+		State  ApplicationDeploymentState `json:"state"`
+		PostId string                     `json:"post_id,omitempty"`
+	} `json:"mattermost"`
+	Telegram struct {
+		State     ApplicationDeploymentState `json:"state"`
+		MessageId int                        `json:"message_id,omitempty"`
+	} `json:"telegram"`
 }
 
 type ApplicationDeploymentSummary struct {
@@ -118,7 +126,7 @@ type ApplicationDeploymentStatus struct {
 }
 
 func CalcApplicationDeploymentStatuses(app *Application, checkConfigs CheckConfigs, now timeseries.Time) []ApplicationDeploymentStatus {
-	durationThreshold := timeseries.Duration(checkConfigs.GetSimple(Checks.DeploymentStatus.Id, app.Id).Threshold)
+	durationThreshold := timeseries.Duration(checkConfigs.GetSimple(Checks.DeploymentStatus.Id, app.Id, app.Category).Threshold)
 	res := make([]ApplicationDeploymentStatus, 0, len(app.Deployments))
 	for i, d := range app.Deployments {
 		last := i == len(app.Deployments)-1
@@ -165,7 +173,7 @@ func CalcApplicationDeploymentStatuses(app *Application, checkConfigs CheckConfi
 func CalcApplicationDeploymentSummary(app *Application, checkConfigs CheckConfigs, t timeseries.Time, curr, prev *MetricsSnapshot) ([]ApplicationDeploymentSummary, Status) {
 	availabilityCfg, _ := checkConfigs.GetAvailability(app.Id)
 	latencyCfg, _ := checkConfigs.GetLatency(app.Id, app.Category)
-	memoryLeakThreshold := int64(checkConfigs.GetSimple(Checks.MemoryLeak.Id, app.Id).Threshold * 1024 * 1024)
+	memoryLeakThreshold := int64(checkConfigs.GetSimple(Checks.MemoryLeak.Id, app.Id, app.Category).Threshold * 1024 * 1024)
 	significantPercentageDifference := 5.0
 
 	status := OK
@@ -54,6 +54,10 @@ func (a ApplicationId) IsZero() bool {
 	return a == ApplicationIdZero
 }
 
+func (a ApplicationId) HasNamespace() bool {
+	return a.Namespace != "" && a.Namespace != "_"
+}
+
 func (a ApplicationId) String() string {
 	return fmt.Sprintf("%s:%s:%s", a.Namespace, a.Kind, a.Name)
 }
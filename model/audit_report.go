@@ -3,25 +3,30 @@ package model
 import (
 	"github.com/coroot/coroot/timeseries"
 	"github.com/coroot/coroot/utils"
-	"strings"
 )
 
 type AuditReportName string
 
 const (
-	AuditReportSLO         AuditReportName = "SLO"
-	AuditReportInstances   AuditReportName = "Instances"
-	AuditReportCPU         AuditReportName = "CPU"
-	AuditReportMemory      AuditReportName = "Memory"
-	AuditReportStorage     AuditReportName = "Storage"
-	AuditReportNetwork     AuditReportName = "Network"
-	AuditReportLogs        AuditReportName = "Logs"
-	AuditReportPostgres    AuditReportName = "Postgres"
-	AuditReportRedis       AuditReportName = "Redis"
-	AuditReportJvm         AuditReportName = "JVM"
-	AuditReportNode        AuditReportName = "Node"
-	AuditReportDeployments AuditReportName = "Deployments"
-	AuditReportProfiling   AuditReportName = "Profiling"
+	AuditReportSLO           AuditReportName = "SLO"
+	AuditReportInstances     AuditReportName = "Instances"
+	AuditReportCPU           AuditReportName = "CPU"
+	AuditReportMemory        AuditReportName = "Memory"
+	AuditReportStorage       AuditReportName = "Storage"
+	AuditReportNetwork       AuditReportName = "Network"
+	AuditReportLogs          AuditReportName = "Logs"
+	AuditReportPostgres      AuditReportName = "Postgres"
+	AuditReportRedis         AuditReportName = "Redis"
+	AuditReportMysql         AuditReportName = "MySQL"
+	AuditReportMongodb       AuditReportName = "MongoDB"
+	AuditReportKafka         AuditReportName = "Kafka"
+	AuditReportElasticsearch AuditReportName = "Elasticsearch"
+	AuditReportRabbitmq      AuditReportName = "RabbitMQ"
+	AuditReportJvm           AuditReportName = "JVM"
+	AuditReportNode          AuditReportName = "Node"
+	AuditReportDeployments   AuditReportName = "Deployments"
+	AuditReportProfiling     AuditReportName = "Profiling"
+	AuditReportCustom        AuditReportName = "Custom"
 )
 
 type AuditReport struct {
@@ -91,6 +96,12 @@ func (c *AuditReport) GetOrCreateTable(header ...string) *Table {
 	return t
 }
 
+func (c *AuditReport) CreateTable(header ...string) *Table {
+	t := &Table{Header: header}
+	c.Widgets = append(c.Widgets, &Widget{Table: t, Width: "100%"})
+	return t
+}
+
 func (c *AuditReport) CreateCheck(cfg CheckConfig) *Check {
 	ch := &Check{
 		Id:                      cfg.Id,
@@ -104,20 +115,18 @@ func (c *AuditReport) CreateCheck(cfg CheckConfig) *Check {
 		items:           utils.NewStringSet(),
 	}
 	switch cfg.Id {
-	case Checks.SLOAvailability.Id:
-		availabilityCfg, _ := c.checkConfigs.GetAvailability(c.app.Id)
-		ch.Threshold = availabilityCfg.ObjectivePercentage
-	case Checks.SLOLatency.Id:
-		latencyCfg, _ := c.checkConfigs.GetLatency(c.app.Id, c.app.Category)
-		ch.Threshold = latencyCfg.ObjectivePercentage
-		ch.ConditionFormatTemplate = strings.Replace(
-			ch.ConditionFormatTemplate,
-			"<bucket>",
-			utils.FormatLatency(latencyCfg.ObjectiveBucket),
-			1,
-		)
+	case Checks.SLOAvailability.Id, Checks.SLOLatency.Id, Checks.SLODependency.Id: // the objective is set per SLI by the auditor
+		ch.Threshold = cfg.DefaultThreshold
 	default:
-		ch.Threshold = c.checkConfigs.GetSimple(cfg.Id, c.app.Id).Threshold
+		if IsCustomCheck(cfg.Id) {
+			ch.Threshold = cfg.DefaultThreshold
+		} else {
+			simple := c.checkConfigs.GetSimple(cfg.Id, c.app.Id, c.app.Category)
+			ch.Threshold = simple.Threshold
+			ch.CriticalThreshold = simple.CriticalThreshold
+			ch.For = simple.For
+			ch.Horizon = simple.Horizon
+		}
 	}
 	c.Checks = append(c.Checks, ch)
 	return ch
@@ -14,6 +14,8 @@ import (
 
 type CheckId string
 
+const CheckEvaluationWindow = timeseries.Hour
+
 type CheckType int
 
 const (
@@ -53,33 +55,51 @@ type CheckConfig struct {
 	Unit                    CheckUnit
 	MessageTemplate         string
 	ConditionFormatTemplate string
+	DefaultAlert            bool
 }
 
 var Checks = struct {
 	index map[CheckId]*CheckConfig
 
-	SLOAvailability        CheckConfig
-	SLOLatency             CheckConfig
-	CPUNode                CheckConfig
-	CPUContainer           CheckConfig
-	MemoryOOM              CheckConfig
-	MemoryLeak             CheckConfig
-	StorageSpace           CheckConfig
-	StorageIO              CheckConfig
-	NetworkRTT             CheckConfig
-	InstanceAvailability   CheckConfig
-	DeploymentStatus       CheckConfig
-	InstanceRestarts       CheckConfig
-	RedisAvailability      CheckConfig
-	RedisLatency           CheckConfig
-	PostgresAvailability   CheckConfig
-	PostgresLatency        CheckConfig
-	PostgresErrors         CheckConfig
-	PostgresReplicationLag CheckConfig
-	PostgresConnections    CheckConfig
-	LogErrors              CheckConfig
-	JvmAvailability        CheckConfig
-	JvmSafepointTime       CheckConfig
+	SLOAvailability                CheckConfig
+	SLOLatency                     CheckConfig
+	SLODependency                  CheckConfig
+	CPUNode                        CheckConfig
+	CPUContainer                   CheckConfig
+	MemoryOOM                      CheckConfig
+	MemoryLeak                     CheckConfig
+	StorageSpace                   CheckConfig
+	StorageIO                      CheckConfig
+	NetworkRTT                     CheckConfig
+	InstanceAvailability           CheckConfig
+	DeploymentStatus               CheckConfig
+	InstanceRestarts               CheckConfig
+	RedisAvailability              CheckConfig
+	RedisLatency                   CheckConfig
+	PostgresAvailability           CheckConfig
+	PostgresLatency                CheckConfig
+	PostgresErrors                 CheckConfig
+	PostgresReplicationLag         CheckConfig
+	PostgresConnections            CheckConfig
+	MysqlAvailability              CheckConfig
+	MysqlLatency                   CheckConfig
+	MysqlReplicationLag            CheckConfig
+	MysqlConnections               CheckConfig
+	MongodbAvailability            CheckConfig
+	MongodbReplicationLag          CheckConfig
+	MongodbConnections             CheckConfig
+	MongodbSlowOperations          CheckConfig
+	KafkaAvailability              CheckConfig
+	KafkaUnderReplicatedPartitions CheckConfig
+	KafkaOfflinePartitions         CheckConfig
+	KafkaConsumerLag               CheckConfig
+	ElasticsearchHealth            CheckConfig
+	ElasticsearchLatency           CheckConfig
+	ElasticsearchRejections        CheckConfig
+	RabbitmqQueueBacklog           CheckConfig
+	LogErrors                      CheckConfig
+	JvmAvailability                CheckConfig
+	JvmSafepointTime               CheckConfig
 }{
 	index: map[CheckId]*CheckConfig{},
 
@@ -99,6 +119,14 @@ var Checks = struct {
 		Unit:                    CheckUnitPercent,
 		ConditionFormatTemplate: "the percentage of requests served faster than <bucket> < <threshold>",
 	},
+	SLODependency: CheckConfig{
+		Type:                    CheckTypeManual,
+		Title:                   "Dependency",
+		MessageTemplate:         `requests to the dependency are failing or slow`,
+		DefaultThreshold:        99,
+		Unit:                    CheckUnitPercent,
+		ConditionFormatTemplate: "the successful request percentage to the dependency < <threshold>",
+	},
 	CPUNode: CheckConfig{
 		Type:                    CheckTypeItemBased,
 		Title:                   "Node CPU utilization",
@@ -229,6 +257,125 @@ var Checks = struct {
 		ConditionFormatTemplate: "the number of connections > <threshold> of `max_connections`",
 		Unit:                    CheckUnitPercent,
 	},
+	MysqlAvailability: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "MySQL availability",
+		DefaultThreshold:        0,
+		MessageTemplate:         `{{.ItemsWithToBe "mysql instance"}} unavailable`,
+		ConditionFormatTemplate: "the number of unavailable mysql instances > <threshold>",
+	},
+	MysqlLatency: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "MySQL latency",
+		DefaultThreshold:        0.1,
+		Unit:                    CheckUnitSecond,
+		MessageTemplate:         `{{.ItemsWithToBe "mysql instance"}} performing slowly`,
+		ConditionFormatTemplate: "the average query execution time of a mysql instance > <threshold>",
+	},
+	MysqlReplicationLag: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "MySQL replication lag",
+		DefaultThreshold:        30,
+		MessageTemplate:         `{{.ItemsWithToBe "mysql replica"}} far behind the primary`,
+		ConditionFormatTemplate: "replication lag > <threshold>",
+		Unit:                    CheckUnitSecond,
+	},
+	MysqlConnections: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "MySQL connections",
+		DefaultThreshold:        90,
+		MessageTemplate:         `{{.ItemsWithHave "mysql instance"}} too many connections`,
+		ConditionFormatTemplate: "the number of connections > <threshold> of `max_connections`",
+		Unit:                    CheckUnitPercent,
+	},
+	MongodbAvailability: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "MongoDB availability",
+		DefaultThreshold:        0,
+		MessageTemplate:         `{{.ItemsWithToBe "mongodb instance"}} unavailable`,
+		ConditionFormatTemplate: "the number of unavailable mongodb instances > <threshold>",
+	},
+	MongodbReplicationLag: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "MongoDB replication lag",
+		DefaultThreshold:        30,
+		MessageTemplate:         `{{.ItemsWithToBe "mongodb secondary"}} far behind the primary`,
+		ConditionFormatTemplate: "replication lag > <threshold>",
+		Unit:                    CheckUnitSecond,
+	},
+	MongodbConnections: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "MongoDB connections",
+		DefaultThreshold:        90,
+		MessageTemplate:         `{{.ItemsWithHave "mongodb instance"}} too many connections`,
+		ConditionFormatTemplate: "the number of connections > <threshold> of the connection limit",
+		Unit:                    CheckUnitPercent,
+	},
+	MongodbSlowOperations: CheckConfig{
+		Type:                    CheckTypeEventBased,
+		Title:                   "MongoDB slow operations",
+		DefaultThreshold:        0,
+		MessageTemplate:         `{{.Count "slow operation"}} occurred`,
+		ConditionFormatTemplate: "the number of slow operations > <threshold>",
+	},
+	KafkaAvailability: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "Kafka availability",
+		DefaultThreshold:        0,
+		MessageTemplate:         `{{.ItemsWithToBe "kafka broker"}} missing from the cluster`,
+		ConditionFormatTemplate: "the number of brokers missing from the cluster metadata > <threshold>",
+	},
+	KafkaUnderReplicatedPartitions: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "Kafka under-replicated partitions",
+		DefaultThreshold:        0,
+		MessageTemplate:         `{{.ItemsWithHave "topic"}} under-replicated partitions`,
+		ConditionFormatTemplate: "the number of topics with under-replicated partitions > <threshold>",
+	},
+	KafkaOfflinePartitions: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "Kafka offline partitions",
+		DefaultThreshold:        0,
+		MessageTemplate:         `{{.ItemsWithHave "topic"}} offline partitions`,
+		ConditionFormatTemplate: "the number of topics with partitions without a leader > <threshold>",
+	},
+	KafkaConsumerLag: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "Kafka consumer lag",
+		DefaultThreshold:        1000,
+		MessageTemplate:         `{{.ItemsWithToBe "consumer group"}} falling behind`,
+		ConditionFormatTemplate: "the consumer group lag > <threshold> messages and keeps growing",
+		DefaultAlert:            true,
+	},
+	ElasticsearchHealth: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "Elasticsearch cluster health",
+		DefaultThreshold:        0,
+		MessageTemplate:         `{{.ItemsWithToBe "cluster"}} not healthy`,
+		ConditionFormatTemplate: "the cluster health (green = 0, yellow = 1, red = 2) > <threshold>",
+	},
+	ElasticsearchLatency: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "Elasticsearch latency",
+		DefaultThreshold:        0.5,
+		Unit:                    CheckUnitSecond,
+		MessageTemplate:         `{{.ItemsWithToBe "elasticsearch node"}} performing slowly`,
+		ConditionFormatTemplate: "the average search or indexing latency of a node > <threshold>",
+	},
+	ElasticsearchRejections: CheckConfig{
+		Type:                    CheckTypeEventBased,
+		Title:                   "Elasticsearch rejections",
+		DefaultThreshold:        0,
+		MessageTemplate:         `{{.Count "task"}} rejected by thread pools`,
+		ConditionFormatTemplate: "the number of tasks rejected by thread pools > <threshold>",
+	},
+	RabbitmqQueueBacklog: CheckConfig{
+		Type:                    CheckTypeItemBased,
+		Title:                   "RabbitMQ queue backlog",
+		DefaultThreshold:        1000,
+		MessageTemplate:         `backlog of {{.Items "queue"}} is growing`,
+		ConditionFormatTemplate: "the number of ready messages in a queue > <threshold> and keeps growing",
+	},
 	LogErrors: CheckConfig{
 		Type:                    CheckTypeEventBased,
 		Title:                   "Errors",
@@ -301,13 +448,16 @@ func (c CheckContext) Value() string {
 }
 
 type Check struct {
-	Id                      CheckId   `json:"id"`
-	Title                   string    `json:"title"`
-	Status                  Status    `json:"status"`
-	Message                 string    `json:"message"`
-	Threshold               float32   `json:"threshold"`
-	Unit                    CheckUnit `json:"unit"`
-	ConditionFormatTemplate string    `json:"condition_format_template"`
+	Id                      CheckId             `json:"id"`
+	Title                   string              `json:"title"`
+	Status                  Status              `json:"status"`
+	Message                 string              `json:"message"`
+	Threshold               float32             `json:"threshold"`
+	CriticalThreshold       *float32            `json:"critical_threshold"`
+	For                     timeseries.Duration `json:"for"`
+	Horizon                 timeseries.Duration `json:"horizon"`
+	Unit                    CheckUnit           `json:"unit"`
+	ConditionFormatTemplate string              `json:"condition_format_template"`
 
 	typ             CheckType
 	messageTemplate string
@@ -315,12 +465,55 @@ type Check struct {
 	count           int64
 	value           float32
 	fired           bool
+	critical        bool
 }
 
 func (ch *Check) SetValue(v float32) {
 	ch.value = v
 }
 
+func CheckSupportsFor(id CheckId) bool {
+	switch id {
+	case Checks.CPUNode.Id, Checks.CPUContainer.Id, Checks.StorageIO.Id, Checks.StorageSpace.Id:
+		return true
+	}
+	return false
+}
+
+func (ch *Check) Exceeds(ts *timeseries.TimeSeries, threshold float32) bool {
+	if ts.IsEmpty() {
+		return false
+	}
+	if ch.For <= 0 {
+		return ts.Last() > threshold
+	}
+	var since, last timeseries.Time
+	above := false
+	iter := ts.Iter()
+	for iter.Next() {
+		t, v := iter.Value()
+		if timeseries.IsNaN(v) {
+			continue
+		}
+		last = t
+		if v <= threshold {
+			above = false
+			continue
+		}
+		if !above {
+			above = true
+			since = t
+		}
+	}
+	return above && last.Sub(since) >= ch.For
+}
+
+func (ch *Check) UpdateSeverity(v float32) {
+	if ch.CriticalThreshold != nil && v > *ch.CriticalThreshold {
+		ch.critical = true
+	}
+}
+
 func (ch *Check) Fire() {
 	ch.fired = true
 }
@@ -348,6 +541,7 @@ func (ch *Check) Calc() {
 		if ch.count <= int64(ch.Threshold) {
 			return
 		}
+		ch.UpdateSeverity(float32(ch.count))
 	case CheckTypeItemBased:
 		if ch.items.Len() == 0 {
 			return
@@ -356,6 +550,7 @@ func (ch *Check) Calc() {
 		if ch.value <= ch.Threshold {
 			return
 		}
+		ch.UpdateSeverity(ch.value)
 	case CheckTypeManual:
 		if !ch.fired {
 			return
@@ -373,14 +568,23 @@ func (ch *Check) Calc() {
 		ch.SetStatus(UNKNOWN, "failed to render message: %s", err)
 		return
 	}
-	ch.SetStatus(WARNING, buf.String())
+	status := WARNING
+	if ch.critical {
+		status = CRITICAL
+	}
+	ch.SetStatus(status, buf.String())
 }
 
 type CheckConfigSimple struct {
-	Threshold float32 `json:"threshold"`
+	Threshold         float32             `json:"threshold"`
+	CriticalThreshold *float32            `json:"critical_threshold,omitempty"`
+	For               timeseries.Duration `json:"for,omitempty"`
+	Horizon           timeseries.Duration `json:"horizon,omitempty"`
+	Alert             bool                `json:"alert"`
 }
 
 type CheckConfigSLOAvailability struct {
+	Name                string  `json:"name,omitempty"`
 	Custom              bool    `json:"custom"`
 	TotalRequestsQuery  string  `json:"total_requests_query"`
 	FailedRequestsQuery string  `json:"failed_requests_query"`
@@ -396,6 +600,7 @@ func (cfg *CheckConfigSLOAvailability) Failed() string {
 }
 
 type CheckConfigSLOLatency struct {
+	Name                string  `json:"name,omitempty"`
 	Custom              bool    `json:"custom"`
 	HistogramQuery      string  `json:"histogram_query"`
 	ObjectiveBucket     float32 `json:"objective_bucket"`
@@ -406,31 +611,91 @@ func (cfg *CheckConfigSLOLatency) Histogram() string {
 	return fmt.Sprintf("sum by(le)(rate(%s[$RANGE]))", cfg.HistogramQuery)
 }
 
+type CheckConfigSLODependency struct {
+	Upstream                        ApplicationId `json:"upstream"`
+	AvailabilityObjectivePercentage float32       `json:"availability_objective_percentage"`
+	LatencyObjectiveBucket          float32       `json:"latency_objective_bucket"`
+	LatencyObjectivePercentage      float32       `json:"latency_objective_percentage"`
+}
+
 type CheckConfigs map[ApplicationId]map[CheckId]json.RawMessage
 
-func (cc CheckConfigs) getRaw(appId ApplicationId, checkId CheckId) json.RawMessage {
-	for _, i := range []ApplicationId{appId, {}} {
+type CheckConfigLevel string
+
+const (
+	CheckConfigLevelDefault     CheckConfigLevel = "default"
+	CheckConfigLevelProject     CheckConfigLevel = "project"
+	CheckConfigLevelCategory    CheckConfigLevel = "category"
+	CheckConfigLevelNamespace   CheckConfigLevel = "namespace"
+	CheckConfigLevelApplication CheckConfigLevel = "application"
+)
+
+const categoryConfigKind ApplicationKind = "@category"
+
+func NamespaceConfigId(namespace string) ApplicationId {
+	return ApplicationId{Namespace: namespace}
+}
+
+func CategoryConfigId(category ApplicationCategory) ApplicationId {
+	return ApplicationId{Kind: categoryConfigKind, Name: string(category)}
+}
+
+func (a ApplicationId) ConfigLevel() CheckConfigLevel {
+	switch {
+	case a.IsZero():
+		return CheckConfigLevelProject
+	case a.Kind == categoryConfigKind && a.Namespace == "":
+		return CheckConfigLevelCategory
+	case a.Kind == "" && a.Name == "":
+		return CheckConfigLevelNamespace
+	}
+	return CheckConfigLevelApplication
+}
+
+// configIds returns the config ids for the application in order of priority: app > namespace > category > project.
+func configIds(appId ApplicationId, category ApplicationCategory) []ApplicationId {
+	var res []ApplicationId
+	if !appId.IsZero() {
+		res = append(res, appId)
+		if appId.HasNamespace() {
+			res = append(res, NamespaceConfigId(appId.Namespace))
+		}
+	}
+	if category != "" {
+		res = append(res, CategoryConfigId(category))
+	}
+	return append(res, ApplicationIdZero)
+}
+
+func (cc CheckConfigs) getRaw(appId ApplicationId, category ApplicationCategory, checkId CheckId) (json.RawMessage, CheckConfigLevel) {
+	for _, i := range configIds(appId, category) {
 		if appConfigs, ok := cc[i]; ok {
 			if cfg, ok := appConfigs[checkId]; ok {
-				return cfg
+				return cfg, i.ConfigLevel()
 			}
 		}
 	}
-	return nil
+	return nil, CheckConfigLevelDefault
 }
 
-func (cc CheckConfigs) GetSimple(checkId CheckId, appId ApplicationId) CheckConfigSimple {
-	cfg := CheckConfigSimple{Threshold: Checks.index[checkId].DefaultThreshold}
-	raw := cc.getRaw(appId, checkId)
+func (cc CheckConfigs) GetSimple(checkId CheckId, appId ApplicationId, category ApplicationCategory) CheckConfigSimple {
+	cfg, _ := cc.GetSimpleWithLevel(checkId, appId, category)
+	return cfg
+}
+
+func (cc CheckConfigs) GetSimpleWithLevel(checkId CheckId, appId ApplicationId, category ApplicationCategory) (CheckConfigSimple, CheckConfigLevel) {
+	def := Checks.index[checkId]
+	cfg := CheckConfigSimple{Threshold: def.DefaultThreshold, Alert: def.DefaultAlert}
+	raw, level := cc.getRaw(appId, category, checkId)
 	if raw == nil {
-		return cfg
+		return cfg, CheckConfigLevelDefault
 	}
 	v, err := unmarshal[CheckConfigSimple](raw)
 	if err != nil {
 		klog.Warningln("failed to unmarshal check config:", err)
-		return cfg
+		return cfg, CheckConfigLevelDefault
 	}
-	return v
+	return v, level
 }
 
 func (cc CheckConfigs) GetSimpleAll(checkId CheckId, appId ApplicationId) []*CheckConfigSimple {
@@ -439,23 +704,74 @@ func (cc CheckConfigs) GetSimpleAll(checkId CheckId, appId ApplicationId) []*Che
 		klog.Warningln("unknown check:", checkId)
 		return nil
 	}
-	res := []*CheckConfigSimple{{Threshold: Checks.index[checkId].DefaultThreshold}}
+	res := []*CheckConfigSimple{{Threshold: def.DefaultThreshold, Alert: def.DefaultAlert}}
 	ids := []ApplicationId{ApplicationIdZero}
 	if !appId.IsZero() {
 		ids = append(ids, appId)
 	}
 	for _, id := range ids {
-		if appConfigs, ok := cc[id]; ok {
-			if raw, ok := appConfigs[checkId]; ok {
-				if cfg, err := unmarshal[CheckConfigSimple](raw); err != nil {
-					klog.Warningln("failed to unmarshal check config:", err)
-				} else {
-					res = append(res, &cfg)
-					continue
-				}
+		res = append(res, cc.GetSimpleOverride(checkId, id))
+	}
+	return res
+}
+
+func (cc CheckConfigs) GetSimpleOverride(checkId CheckId, id ApplicationId) *CheckConfigSimple {
+	raw, ok := cc[id][checkId]
+	if !ok {
+		return nil
+	}
+	cfg, err := unmarshal[CheckConfigSimple](raw)
+	if err != nil {
+		klog.Warningln("failed to unmarshal check config:", err)
+		return nil
+	}
+	return &cfg
+}
+
+func (cc CheckConfigs) GetAlertRules(appId ApplicationId) []AlertRule {
+	all := cc.GetAlertRulesAll(appId)
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i] != nil {
+			return all[i]
+		}
+	}
+	return DefaultAlertRules
+}
+
+func (cc CheckConfigs) GetAlertRulesAll(appId ApplicationId) [][]AlertRule {
+	res := [][]AlertRule{DefaultAlertRules}
+	ids := []ApplicationId{ApplicationIdZero}
+	if !appId.IsZero() {
+		ids = append(ids, appId)
+	}
+	for _, id := range ids {
+		var rules []AlertRule
+		if raw := cc[id][AlertRulesId]; raw != nil {
+			if v, err := unmarshal[[]AlertRule](raw); err != nil {
+				klog.Warningln("failed to unmarshal alert rules:", err)
+			} else if len(v) > 0 {
+				rules = v
 			}
 		}
-		res = append(res, nil)
+		res = append(res, rules)
+	}
+	return res
+}
+
+func (cc CheckConfigs) MaxAlertRuleWindow() timeseries.Duration {
+	res := MaxAlertRuleWindow(DefaultAlertRules)
+	for _, appConfigs := range cc {
+		raw := appConfigs[AlertRulesId]
+		if raw == nil {
+			continue
+		}
+		rules, err := unmarshal[[]AlertRule](raw)
+		if err != nil {
+			continue
+		}
+		if w := MaxAlertRuleWindow(rules); w > res {
+			res = w
+		}
 	}
 	return res
 }
@@ -474,6 +790,8 @@ func (cc CheckConfigs) GetByCheck(id CheckId) map[ApplicationId][]any {
 				cfg, err = unmarshal[[]CheckConfigSLOAvailability](raw)
 			case Checks.SLOLatency.Id:
 				cfg, err = unmarshal[[]CheckConfigSLOLatency](raw)
+			case Checks.SLODependency.Id:
+				cfg, err = unmarshal[[]CheckConfigSLODependency](raw)
 			default:
 				cfg, err = unmarshal[CheckConfigSimple](raw)
 			}
@@ -488,10 +806,15 @@ func (cc CheckConfigs) GetByCheck(id CheckId) map[ApplicationId][]any {
 }
 
 func (cc CheckConfigs) GetAvailability(appId ApplicationId) (CheckConfigSLOAvailability, bool) {
-	defaultCfg := CheckConfigSLOAvailability{
+	res, def := cc.GetAvailabilityAll(appId)
+	return res[0], def
+}
+
+func (cc CheckConfigs) GetAvailabilityAll(appId ApplicationId) ([]CheckConfigSLOAvailability, bool) {
+	defaultCfg := []CheckConfigSLOAvailability{{
 		Custom:              false,
 		ObjectivePercentage: Checks.SLOAvailability.DefaultThreshold,
-	}
+	}}
 	appConfigs := cc[appId]
 	if appConfigs == nil {
 		return defaultCfg, true
@@ -508,19 +831,24 @@ func (cc CheckConfigs) GetAvailability(appId ApplicationId) (CheckConfigSLOAvail
 	if len(res) == 0 {
 		return defaultCfg, true
 	}
-	return res[0], false
+	return res, false
 }
 
 func (cc CheckConfigs) GetLatency(appId ApplicationId, category ApplicationCategory) (CheckConfigSLOLatency, bool) {
+	res, def := cc.GetLatencyAll(appId, category)
+	return res[0], def
+}
+
+func (cc CheckConfigs) GetLatencyAll(appId ApplicationId, category ApplicationCategory) ([]CheckConfigSLOLatency, bool) {
 	objectiveBucket := float32(0.5)
 	if category.Auxiliary() {
 		objectiveBucket = 5
 	}
-	defaultCfg := CheckConfigSLOLatency{
+	defaultCfg := []CheckConfigSLOLatency{{
 		Custom:              false,
 		ObjectivePercentage: Checks.SLOLatency.DefaultThreshold,
 		ObjectiveBucket:     objectiveBucket,
-	}
+	}}
 	appConfigs := cc[appId]
 	if appConfigs == nil {
 		return defaultCfg, true
@@ -537,7 +865,20 @@ func (cc CheckConfigs) GetLatency(appId ApplicationId, category ApplicationCateg
 	if len(res) == 0 {
 		return defaultCfg, true
 	}
-	return res[0], false
+	return res, false
+}
+
+func (cc CheckConfigs) GetDependencies(appId ApplicationId) []CheckConfigSLODependency {
+	raw := cc[appId][Checks.SLODependency.Id]
+	if raw == nil {
+		return nil
+	}
+	res, err := unmarshal[[]CheckConfigSLODependency](raw)
+	if err != nil {
+		klog.Warningln("failed to unmarshal check config:", err)
+		return nil
+	}
+	return res
 }
 
 func unmarshal[T any](raw json.RawMessage) (T, error) {
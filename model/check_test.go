@@ -0,0 +1,159 @@
+package model
+
+import (
+	"encoding/json"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetAlertRules(t *testing.T) {
+	appId := NewApplicationId("default", ApplicationKindDeployment, "catalog")
+	projectRules := `[{"long_window":3600000,"short_window":300000,"burn_rate_threshold":10,"severity":"critical"}]`
+	appRules := `[{"long_window":7200000,"short_window":600000,"burn_rate_threshold":5,"severity":"warning"}]`
+
+	tests := []struct {
+		name     string
+		configs  map[ApplicationId]string
+		expected []AlertRule
+	}{
+		{
+			name:     "default",
+			expected: DefaultAlertRules,
+		},
+		{
+			name:     "project level",
+			configs:  map[ApplicationId]string{ApplicationIdZero: projectRules},
+			expected: []AlertRule{{LongWindow: timeseries.Hour, ShortWindow: 5 * timeseries.Minute, BurnRateThreshold: 10, Severity: CRITICAL}},
+		},
+		{
+			name:     "app level overrides project level",
+			configs:  map[ApplicationId]string{ApplicationIdZero: projectRules, appId: appRules},
+			expected: []AlertRule{{LongWindow: 2 * timeseries.Hour, ShortWindow: 10 * timeseries.Minute, BurnRateThreshold: 5, Severity: WARNING}},
+		},
+		{
+			name:     "empty app level",
+			configs:  map[ApplicationId]string{ApplicationIdZero: projectRules, appId: `[]`},
+			expected: []AlertRule{{LongWindow: timeseries.Hour, ShortWindow: 5 * timeseries.Minute, BurnRateThreshold: 10, Severity: CRITICAL}},
+		},
+		{
+			name:     "unknown severity",
+			configs:  map[ApplicationId]string{appId: `[{"long_window":3600000,"short_window":300000,"burn_rate_threshold":10,"severity":"fatal"}]`},
+			expected: DefaultAlertRules,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := CheckConfigs{}
+			for id, raw := range tt.configs {
+				cc[id] = map[CheckId]json.RawMessage{AlertRulesId: json.RawMessage(raw)}
+			}
+			assert.Equal(t, tt.expected, cc.GetAlertRules(appId))
+		})
+	}
+}
+
+func TestStatusUnmarshalJSON(t *testing.T) {
+	for _, tt := range []struct {
+		src      string
+		expected Status
+		valid    bool
+	}{
+		{`"ok"`, OK, true},
+		{`"warning"`, WARNING, true},
+		{`"critical"`, CRITICAL, true},
+		{`"unknown"`, UNKNOWN, true},
+		{`4`, CRITICAL, true},
+		{`"fatal"`, UNKNOWN, false},
+		{`""`, UNKNOWN, false},
+	} {
+		var s Status
+		err := json.Unmarshal([]byte(tt.src), &s)
+		assert.Equal(t, tt.valid, err == nil, tt.src)
+		assert.Equal(t, tt.expected, s, tt.src)
+	}
+}
+
+func TestSLOStatus(t *testing.T) {
+	app := &Application{Reports: []*AuditReport{{
+		Name: AuditReportSLO,
+		Checks: []*Check{
+			{Id: Checks.SLOAvailability.Id, Status: OK},
+			{Id: Checks.SLODependency.Id, Status: CRITICAL},
+			{Id: Checks.SLODependency.Id, Status: WARNING},
+		},
+	}}}
+	assert.Equal(t, OK, app.SLOStatus())
+	assert.Equal(t, CRITICAL, app.DependencySLOStatus())
+
+	app.Reports[0].Checks = app.Reports[0].Checks[1:]
+	assert.Equal(t, UNKNOWN, app.SLOStatus())
+	assert.Equal(t, CRITICAL, app.DependencySLOStatus())
+
+	app.Reports = nil
+	assert.Equal(t, UNKNOWN, app.SLOStatus())
+	assert.Equal(t, UNKNOWN, app.DependencySLOStatus())
+}
+
+func TestCheckExceeds(t *testing.T) {
+	nan := timeseries.NaN
+	tests := []struct {
+		name     string
+		values   []float32
+		forValue timeseries.Duration
+		exceeds  bool
+	}{
+		{name: "empty", values: nil},
+		{name: "last above, no for", values: []float32{10, 90}, exceeds: true},
+		{name: "last below, no for", values: []float32{90, 10}},
+		{name: "above for the whole duration", values: []float32{90, 90, 90}, forValue: 60, exceeds: true},
+		{name: "above for less than the duration", values: []float32{10, 90, 90}, forValue: 60},
+		{name: "trailing nan", values: []float32{90, 90, 90, nan}, forValue: 60, exceeds: true},
+		{name: "gap", values: []float32{90, nan, 90}, forValue: 60, exceeds: true},
+		{name: "dropped below", values: []float32{90, 90, 10, 90}, forValue: 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := &Check{For: tt.forValue}
+			var ts *timeseries.TimeSeries
+			if tt.values != nil {
+				ts = timeseries.NewWithData(0, 30, tt.values)
+			}
+			assert.Equal(t, tt.exceeds, ch.Exceeds(ts, 80))
+		})
+	}
+}
+
+func TestConfigIds(t *testing.T) {
+	k8sApp := NewApplicationId("default", ApplicationKindDeployment, "catalog")
+	standalone := NewApplicationId("", ApplicationKindUnknown, "catalog")
+	noNamespace := ApplicationId{Kind: ApplicationKindExternalService, Name: "payments"}
+
+	assert.Equal(t,
+		[]ApplicationId{k8sApp, NamespaceConfigId("default"), CategoryConfigId("application"), ApplicationIdZero},
+		configIds(k8sApp, "application"),
+	)
+	assert.Equal(t,
+		[]ApplicationId{standalone, CategoryConfigId("application"), ApplicationIdZero},
+		configIds(standalone, "application"),
+	)
+	assert.Equal(t, []ApplicationId{standalone, ApplicationIdZero}, configIds(standalone, ""))
+	assert.Equal(t,
+		[]ApplicationId{noNamespace, CategoryConfigId("application"), ApplicationIdZero},
+		configIds(noNamespace, "application"),
+	)
+	assert.Equal(t, []ApplicationId{ApplicationIdZero}, configIds(ApplicationIdZero, ""))
+
+	cfg := `{"threshold": 50}`
+	cc := CheckConfigs{
+		ApplicationIdZero:            {Checks.CPUNode.Id: json.RawMessage(`{"threshold": 70}`)},
+		NamespaceConfigId("default"): {Checks.CPUNode.Id: json.RawMessage(cfg)},
+		NamespaceConfigId("_"):       {Checks.CPUNode.Id: json.RawMessage(cfg)},
+	}
+	c, level := cc.GetSimpleWithLevel(Checks.CPUNode.Id, k8sApp, "")
+	assert.Equal(t, float32(50), c.Threshold)
+	assert.Equal(t, CheckConfigLevelNamespace, level)
+	c, level = cc.GetSimpleWithLevel(Checks.CPUNode.Id, standalone, "")
+	assert.Equal(t, float32(70), c.Threshold)
+	assert.Equal(t, CheckConfigLevelProject, level)
+}
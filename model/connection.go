@@ -24,6 +24,9 @@ type Connection struct {
 	RequestsLatency   map[Protocol]*timeseries.TimeSeries
 	RequestsHistogram map[Protocol]map[float32]*timeseries.TimeSeries // by le
 
+	RequestsCountByRoute     map[string]map[string]*timeseries.TimeSeries  // by route, by status
+	RequestsHistogramByRoute map[string]map[float32]*timeseries.TimeSeries // by route, by le
+
 	ServiceRemoteIP   string
 	ServiceRemotePort string
 }
@@ -0,0 +1,116 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/coroot/coroot/timeseries"
+	"strings"
+	"text/template"
+)
+
+const customCheckIdPrefix = "Custom:"
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+type CustomCheck struct {
+	Id              string              `json:"id"`
+	Title           string              `json:"title"`
+	Query           string              `json:"query"`
+	Type            CheckType           `json:"type"`
+	Threshold       float32             `json:"threshold"`
+	Unit            CheckUnit           `json:"unit"`
+	MessageTemplate string              `json:"message_template"`
+	ApplicationId   ApplicationId       `json:"application_id"`
+	Category        ApplicationCategory `json:"category"`
+	Alert           bool                `json:"alert"`
+}
+
+func (cc *CustomCheck) Validate() error {
+	if cc.Id == "" || cc.Title == "" || cc.Query == "" {
+		return fmt.Errorf("id, title and query are required")
+	}
+	switch cc.Type {
+	case CheckTypeEventBased, CheckTypeItemBased, CheckTypeValueBased:
+	default:
+		return fmt.Errorf("unsupported check type: %d", cc.Type)
+	}
+	if cc.ApplicationId.IsZero() == (cc.Category == "") {
+		return fmt.Errorf("either application_id or category must be specified")
+	}
+	if _, err := template.New("").Parse(cc.Query); err != nil {
+		return fmt.Errorf("invalid query template: %w", err)
+	}
+	if _, err := template.New("").Parse(cc.MessageTemplate); err != nil {
+		return fmt.Errorf("invalid message template: %w", err)
+	}
+	return nil
+}
+
+func (cc *CustomCheck) CheckId() CheckId {
+	return CheckId(customCheckIdPrefix + cc.Id)
+}
+
+func (cc *CustomCheck) Matches(appId ApplicationId, category ApplicationCategory) bool {
+	if !cc.ApplicationId.IsZero() {
+		return cc.ApplicationId == appId
+	}
+	return cc.Category == category
+}
+
+func (cc *CustomCheck) RenderQuery(appId ApplicationId) (string, error) {
+	t, err := template.New("").Parse(cc.Query)
+	if err != nil {
+		return "", err
+	}
+	data := ApplicationId{
+		Namespace: labelValueEscaper.Replace(appId.Namespace),
+		Kind:      ApplicationKind(labelValueEscaper.Replace(string(appId.Kind))),
+		Name:      labelValueEscaper.Replace(appId.Name),
+	}
+	buf := &bytes.Buffer{}
+	if err = t.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (cc *CustomCheck) CheckConfig() CheckConfig {
+	messageTemplate := cc.MessageTemplate
+	if messageTemplate == "" {
+		switch cc.Type {
+		case CheckTypeItemBased:
+			messageTemplate = `{{.Items "item"}} exceeded the threshold`
+		case CheckTypeEventBased:
+			messageTemplate = `{{.Count "event"}} occurred`
+		default:
+			messageTemplate = `the value is {{.Value}}`
+		}
+	}
+	return CheckConfig{
+		Id:                      cc.CheckId(),
+		Type:                    cc.Type,
+		Title:                   cc.Title,
+		DefaultThreshold:        cc.Threshold,
+		Unit:                    cc.Unit,
+		MessageTemplate:         messageTemplate,
+		ConditionFormatTemplate: "the value of the query > <threshold>",
+	}
+}
+
+func IsCustomCheck(id CheckId) bool {
+	return strings.HasPrefix(string(id), customCheckIdPrefix)
+}
+
+type CustomCheckData struct {
+	Check  *CustomCheck
+	Values []MetricValues
+	Error  string
+}
+
+func (d *CustomCheckData) Sum() *timeseries.TimeSeries {
+	sum := timeseries.NewAggregate(timeseries.NanSum)
+	for _, mv := range d.Values {
+		sum.Add(mv.Values)
+	}
+	return sum.Get()
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCustomCheckRenderQuery(t *testing.T) {
+	cc := &CustomCheck{Query: `sum(rate(errors_total{namespace="{{.Namespace}}", app="{{.Name}}"}[5m]))`}
+
+	q, err := cc.RenderQuery(NewApplicationId("default", ApplicationKindDeployment, "catalog"))
+	require.NoError(t, err)
+	assert.Equal(t, `sum(rate(errors_total{namespace="default", app="catalog"}[5m]))`, q)
+
+	q, err = cc.RenderQuery(NewApplicationId("default", ApplicationKindDeployment, `cat"alog\`))
+	require.NoError(t, err)
+	assert.Equal(t, `sum(rate(errors_total{namespace="default", app="cat\"alog\\"}[5m]))`, q)
+
+	cc.Query = "{{.Name"
+	_, err = cc.RenderQuery(NewApplicationId("default", ApplicationKindDeployment, "catalog"))
+	assert.Error(t, err)
+}
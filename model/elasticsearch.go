@@ -0,0 +1,40 @@
+package model
+
+import (
+	"github.com/coroot/coroot/timeseries"
+)
+
+type Elasticsearch struct {
+	Cluster LabelLastValue
+	Health  LabelLastValue
+
+	UnassignedShards *timeseries.TimeSeries
+
+	HeapUsed *timeseries.TimeSeries
+	HeapMax  *timeseries.TimeSeries
+
+	IndexingRate    *timeseries.TimeSeries
+	IndexingLatency *timeseries.TimeSeries
+	SearchRate      *timeseries.TimeSeries
+	SearchLatency   *timeseries.TimeSeries
+
+	RejectedTasks map[string]*timeseries.TimeSeries
+}
+
+func NewElasticsearch() *Elasticsearch {
+	return &Elasticsearch{
+		RejectedTasks: map[string]*timeseries.TimeSeries{},
+	}
+}
+
+func (es *Elasticsearch) HealthScore() float32 {
+	switch es.Health.Value() {
+	case "green":
+		return 0
+	case "yellow":
+		return 1
+	case "red":
+		return 2
+	}
+	return timeseries.NaN
+}
@@ -48,8 +48,13 @@ type Instance struct {
 	ClusterName LabelLastValue
 	clusterRole *timeseries.TimeSeries
 
-	Postgres *Postgres
-	Redis    *Redis
+	Postgres      *Postgres
+	Redis         *Redis
+	Mysql         *Mysql
+	Mongodb       *Mongodb
+	Kafka         *Kafka
+	Rabbitmq      *Rabbitmq
+	Elasticsearch *Elasticsearch
 }
 
 func NewInstance(name string, owner ApplicationId) *Instance {
@@ -79,6 +84,16 @@ func (instance *Instance) InstrumentedType() ApplicationType {
 		return ApplicationTypePostgres
 	case instance.Redis != nil:
 		return ApplicationTypeRedis
+	case instance.Mysql != nil:
+		return ApplicationTypeMysql
+	case instance.Mongodb != nil:
+		return ApplicationTypeMongodb
+	case instance.Kafka != nil:
+		return ApplicationTypeKafka
+	case instance.Elasticsearch != nil:
+		return ApplicationTypeElasticsearch
+	case instance.Rabbitmq != nil:
+		return ApplicationTypeRabbitmq
 	}
 	return ApplicationTypeUnknown
 }
@@ -104,6 +119,9 @@ func (instance *Instance) AddUpstreamConnection(actualIP, actualPort, serviceIP,
 		RequestsCount:     map[Protocol]map[string]*timeseries.TimeSeries{},
 		RequestsLatency:   map[Protocol]*timeseries.TimeSeries{},
 		RequestsHistogram: map[Protocol]map[float32]*timeseries.TimeSeries{},
+
+		RequestsCountByRoute:     map[string]map[string]*timeseries.TimeSeries{},
+		RequestsHistogramByRoute: map[string]map[float32]*timeseries.TimeSeries{},
 	}
 	instance.Upstreams = append(instance.Upstreams, c)
 	return c
@@ -135,6 +153,15 @@ func (instance *Instance) UpdateClusterRole(role string, v *timeseries.TimeSerie
 	}
 }
 
+// MergeClusterRole is like UpdateClusterRole but keeps the previously set role for timestamps where the role is unknown.
+func (instance *Instance) MergeClusterRole(role string, v *timeseries.TimeSeries) {
+	prev := instance.clusterRole
+	instance.UpdateClusterRole(role, v)
+	if prev != nil && instance.clusterRole != prev {
+		instance.clusterRole = timeseries.NewAggregate(timeseries.Any).Add(prev, instance.clusterRole).Get()
+	}
+}
+
 func (instance *Instance) ClusterRole() *timeseries.TimeSeries {
 	if instance.Pod == nil || instance.Pod.Ready.IsEmpty() || instance.clusterRole.IsEmpty() {
 		return instance.clusterRole
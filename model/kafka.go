@@ -0,0 +1,33 @@
+package model
+
+import (
+	"fmt"
+	"github.com/coroot/coroot/timeseries"
+)
+
+type KafkaConsumerGroupKey struct {
+	Group string
+	Topic string
+}
+
+func (k KafkaConsumerGroupKey) String() string {
+	return fmt.Sprintf("%s@%s", k.Group, k.Topic)
+}
+
+type Kafka struct {
+	Brokers map[string]*timeseries.TimeSeries
+
+	UnderReplicatedPartitions map[string]*timeseries.TimeSeries
+	OfflinePartitions         map[string]*timeseries.TimeSeries
+
+	ConsumerGroupLag map[KafkaConsumerGroupKey]*timeseries.TimeSeries
+}
+
+func NewKafka() *Kafka {
+	return &Kafka{
+		Brokers:                   map[string]*timeseries.TimeSeries{},
+		UnderReplicatedPartitions: map[string]*timeseries.TimeSeries{},
+		OfflinePartitions:         map[string]*timeseries.TimeSeries{},
+		ConsumerGroupLag:          map[KafkaConsumerGroupKey]*timeseries.TimeSeries{},
+	}
+}
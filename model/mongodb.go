@@ -0,0 +1,33 @@
+package model
+
+import (
+	"github.com/coroot/coroot/timeseries"
+)
+
+const (
+	MongodbStatePrimary   = 1
+	MongodbStateSecondary = 2
+)
+
+type Mongodb struct {
+	Up         *timeseries.TimeSeries
+	Version    LabelLastValue
+	ReplicaSet LabelLastValue
+	State      *timeseries.TimeSeries
+
+	LastAppliedOptime *timeseries.TimeSeries
+
+	Connections          *timeseries.TimeSeries
+	AvailableConnections *timeseries.TimeSeries
+
+	Ops     *timeseries.TimeSeries
+	SlowOps *timeseries.TimeSeries
+}
+
+func NewMongodb() *Mongodb {
+	return &Mongodb{}
+}
+
+func (m *Mongodb) IsUp() bool {
+	return m.Up.Last() > 0
+}
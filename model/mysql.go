@@ -0,0 +1,40 @@
+package model
+
+import (
+	"github.com/coroot/coroot/timeseries"
+)
+
+type Mysql struct {
+	Up      *timeseries.TimeSeries
+	Version LabelLastValue
+
+	Connections    *timeseries.TimeSeries
+	MaxConnections *timeseries.TimeSeries
+
+	ReplicationLag *timeseries.TimeSeries
+
+	Queries     *timeseries.TimeSeries
+	SlowQueries *timeseries.TimeSeries
+
+	StatementsCount *timeseries.TimeSeries
+	StatementsTime  *timeseries.TimeSeries
+
+	RowLockWaits *timeseries.TimeSeries
+	RowLockTime  *timeseries.TimeSeries
+}
+
+func NewMysql() *Mysql {
+	return &Mysql{}
+}
+
+func (m *Mysql) IsUp() bool {
+	return m.Up.Last() > 0
+}
+
+func (m *Mysql) IsReplica() bool {
+	return !m.ReplicationLag.IsEmpty()
+}
+
+func (m *Mysql) Latency() *timeseries.TimeSeries {
+	return timeseries.Div(m.StatementsTime, m.StatementsCount)
+}
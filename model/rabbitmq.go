@@ -0,0 +1,35 @@
+package model
+
+import (
+	"github.com/coroot/coroot/timeseries"
+)
+
+type RabbitmqQueueKey struct {
+	Vhost string
+	Name  string
+}
+
+func (k RabbitmqQueueKey) String() string {
+	if k.Vhost == "" || k.Vhost == "/" {
+		return k.Name
+	}
+	return k.Vhost + "/" + k.Name
+}
+
+type RabbitmqQueue struct {
+	Ready       *timeseries.TimeSeries
+	Unacked     *timeseries.TimeSeries
+	Consumers   *timeseries.TimeSeries
+	PublishRate *timeseries.TimeSeries
+	DeliverRate *timeseries.TimeSeries
+}
+
+type Rabbitmq struct {
+	Queues map[RabbitmqQueueKey]*RabbitmqQueue
+}
+
+func NewRabbitmq() *Rabbitmq {
+	return &Rabbitmq{
+		Queues: map[RabbitmqQueueKey]*RabbitmqQueue{},
+	}
+}
@@ -0,0 +1,19 @@
+package model
+
+type RootCauseHintType string
+
+const (
+	RootCauseHintTypeRollout    RootCauseHintType = "rollout"
+	RootCauseHintTypeUpstream   RootCauseHintType = "upstream"
+	RootCauseHintTypeDownstream RootCauseHintType = "downstream"
+	RootCauseHintTypeNode       RootCauseHintType = "node"
+	RootCauseHintTypeCheck      RootCauseHintType = "check"
+)
+
+type RootCauseHint struct {
+	Type          RootCauseHintType `json:"type"`
+	ApplicationId ApplicationId     `json:"application_id"`
+	Title         string            `json:"title"`
+	Explanation   string            `json:"explanation"`
+	Score         float32           `json:"score"`
+}
@@ -14,6 +14,21 @@ type AvailabilitySLI struct {
 	FailedRequestsRaw *timeseries.TimeSeries
 }
 
+type DependencySLI struct {
+	Upstream ApplicationId
+
+	Availability *AvailabilitySLI
+	Latency      *LatencySLI
+}
+
+type RouteSLI struct {
+	Route string
+
+	TotalRequests  *timeseries.TimeSeries
+	FailedRequests *timeseries.TimeSeries
+	Histogram      []HistogramBucket
+}
+
 type HistogramBucket struct {
 	Le         float32
 	TimeSeries *timeseries.TimeSeries
@@ -1,6 +1,9 @@
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 const (
 	UNKNOWN Status = iota
@@ -31,6 +34,33 @@ func (s Status) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s.String())
 }
 
+func (s *Status) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		var i int
+		if err := json.Unmarshal(b, &i); err != nil {
+			return err
+		}
+		*s = Status(i)
+		return nil
+	}
+	switch str {
+	case "ok":
+		*s = OK
+	case "info":
+		*s = INFO
+	case "warning":
+		*s = WARNING
+	case "critical":
+		*s = CRITICAL
+	case "unknown":
+		*s = UNKNOWN
+	default:
+		return fmt.Errorf("unknown status: %q", str)
+	}
+	return nil
+}
+
 func (s Status) Color() string {
 	switch s {
 	case OK:
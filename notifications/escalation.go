@@ -0,0 +1,106 @@
+package notifications
+
+import (
+	"fmt"
+	"github.com/coroot/coroot/db"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"k8s.io/klog"
+)
+
+func (n *IncidentNotifier) escalateIncidents() {
+	projects, err := n.db.GetProjects()
+	if err != nil {
+		klog.Errorln(err)
+		return
+	}
+	now := timeseries.Now()
+	for _, project := range projects {
+		policy := project.Settings.EscalationPolicy
+		if policy == nil {
+			continue
+		}
+		incidents, err := n.db.GetOpenIncidents(project.Id)
+		if err != nil {
+			klog.Errorln(err)
+			continue
+		}
+		for i := range incidents {
+			incident := &incidents[i]
+			if incident.Acknowledged() {
+				continue
+			}
+			notifications, err := n.db.GetIncidentNotifications(project.Id, incident.Key)
+			if err != nil {
+				klog.Errorln(err)
+				continue
+			}
+			n.renotify(project, policy, notifications, now)
+			n.escalate(project, policy, incident, notifications, now)
+		}
+	}
+}
+
+func (n *IncidentNotifier) renotify(project *db.Project, policy *db.EscalationPolicy, notifications []db.IncidentNotification, now timeseries.Time) {
+	if policy.RenotifyInterval <= 0 {
+		return
+	}
+	last := map[db.IntegrationType]db.IncidentNotification{}
+	pendingReminder := map[db.IntegrationType]bool{}
+	for _, notification := range notifications {
+		last[notification.Destination] = notification
+		if notification.Reason == db.IncidentNotificationReasonReminder && notification.SentAt.IsZero() && notification.DiscardedAt.IsZero() {
+			pendingReminder[notification.Destination] = true
+		}
+	}
+	for destination, notification := range last {
+		switch destination {
+		case db.IntegrationTypeSlack, db.IntegrationTypeTeams, db.IntegrationTypeMattermost, db.IntegrationTypeTelegram:
+		default: // PagerDuty and Opsgenie have their own re-notification rules
+			continue
+		}
+		if pendingReminder[destination] || !incidentsEnabled(project, destination) {
+			continue
+		}
+		if notification.Status <= model.OK || now.Sub(notification.Timestamp) < policy.RenotifyInterval {
+			continue
+		}
+		notification.Timestamp = now
+		notification.SentAt = 0
+		notification.Reason = db.IncidentNotificationReasonReminder
+		n.db.PutIncidentNotification(notification)
+	}
+}
+
+func (n *IncidentNotifier) escalate(project *db.Project, policy *db.EscalationPolicy, incident *db.Incident, notifications []db.IncidentNotification, now timeseries.Time) {
+	if policy.EscalateTo == "" || policy.EscalateAfter <= 0 || now.Sub(incident.OpenedAt) < policy.EscalateAfter {
+		return
+	}
+	if !incidentsEnabled(project, policy.EscalateTo) {
+		return
+	}
+	var details *db.IncidentNotificationDetails
+	for _, notification := range notifications {
+		if notification.Destination == policy.EscalateTo {
+			return
+		}
+		if notification.Status > model.OK && notification.Details != nil {
+			details = notification.Details
+		}
+	}
+	notification := db.IncidentNotification{
+		ProjectId:     project.Id,
+		ApplicationId: incident.ApplicationId,
+		IncidentKey:   incident.Key,
+		Status:        incident.Severity,
+		Destination:   policy.EscalateTo,
+		Timestamp:     now,
+		Reason:        db.IncidentNotificationReasonEscalation,
+	}
+	switch policy.EscalateTo {
+	case db.IntegrationTypePagerduty, db.IntegrationTypeOpsgenie:
+		n.onOpen(fmt.Sprintf("%s:%s:%s", project.Id, incident.Key, incident.Severity.String()), notification, details)
+	default:
+		n.onOpen("", notification, details)
+	}
+}
@@ -0,0 +1,190 @@
+package notifications
+
+import (
+	"github.com/coroot/coroot/db"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newTestNotifier(t *testing.T, integrations db.Integrations) (*IncidentNotifier, *db.Project) {
+	database, err := db.Open(t.TempDir(), "")
+	require.NoError(t, err)
+	id, err := database.SaveProject(db.Project{Name: "test"})
+	require.NoError(t, err)
+	project, err := database.GetProject(id)
+	require.NoError(t, err)
+	project.Settings.Integrations = integrations
+	n := &IncidentNotifier{
+		db:       database,
+		sent:     prometheus.NewCounterVec(prometheus.CounterOpts{Name: "sent"}, []string{"destination"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "failures"}, []string{"destination"}),
+	}
+	return n, project
+}
+
+func TestRenotify(t *testing.T) {
+	appId := model.NewApplicationId("default", model.ApplicationKindDeployment, "catalog")
+	policy := &db.EscalationPolicy{RenotifyInterval: 30 * timeseries.Minute}
+	now := timeseries.Time(10000)
+
+	notification := func(projectId db.ProjectId, destination db.IntegrationType, ts timeseries.Time, status model.Status, reason db.IncidentNotificationReason, sentAt timeseries.Time) db.IncidentNotification {
+		return db.IncidentNotification{
+			ProjectId:     projectId,
+			ApplicationId: appId,
+			IncidentKey:   "i1",
+			Status:        status,
+			Destination:   destination,
+			Timestamp:     ts,
+			SentAt:        sentAt,
+			Reason:        reason,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		integrations  db.Integrations
+		notifications func(projectId db.ProjectId) []db.IncidentNotification
+		reminders     int
+	}{
+		{
+			name:         "interval elapsed",
+			integrations: db.Integrations{Slack: &db.IntegrationSlack{Incidents: true}},
+			notifications: func(p db.ProjectId) []db.IncidentNotification {
+				return []db.IncidentNotification{notification(p, db.IntegrationTypeSlack, now.Add(-31*timeseries.Minute), model.CRITICAL, "", now.Add(-31*timeseries.Minute))}
+			},
+			reminders: 1,
+		},
+		{
+			name:         "interval not elapsed",
+			integrations: db.Integrations{Slack: &db.IntegrationSlack{Incidents: true}},
+			notifications: func(p db.ProjectId) []db.IncidentNotification {
+				return []db.IncidentNotification{notification(p, db.IntegrationTypeSlack, now.Add(-10*timeseries.Minute), model.CRITICAL, "", now.Add(-10*timeseries.Minute))}
+			},
+		},
+		{
+			name:         "resolved",
+			integrations: db.Integrations{Slack: &db.IntegrationSlack{Incidents: true}},
+			notifications: func(p db.ProjectId) []db.IncidentNotification {
+				return []db.IncidentNotification{notification(p, db.IntegrationTypeSlack, now.Add(-31*timeseries.Minute), model.OK, "", now.Add(-31*timeseries.Minute))}
+			},
+		},
+		{
+			name:         "incidents disabled",
+			integrations: db.Integrations{Slack: &db.IntegrationSlack{Incidents: false}},
+			notifications: func(p db.ProjectId) []db.IncidentNotification {
+				return []db.IncidentNotification{notification(p, db.IntegrationTypeSlack, now.Add(-31*timeseries.Minute), model.CRITICAL, "", now.Add(-31*timeseries.Minute))}
+			},
+		},
+		{
+			name:         "unsent reminder exists",
+			integrations: db.Integrations{Slack: &db.IntegrationSlack{Incidents: true}},
+			notifications: func(p db.ProjectId) []db.IncidentNotification {
+				return []db.IncidentNotification{
+					notification(p, db.IntegrationTypeSlack, now.Add(-90*timeseries.Minute), model.CRITICAL, "", now.Add(-90*timeseries.Minute)),
+					notification(p, db.IntegrationTypeSlack, now.Add(-45*timeseries.Minute), model.CRITICAL, db.IncidentNotificationReasonReminder, 0),
+				}
+			},
+			reminders: 1,
+		},
+		{
+			name:         "pagerduty",
+			integrations: db.Integrations{Pagerduty: &db.IntegrationPagerduty{IntegrationKey: "key", Incidents: true}},
+			notifications: func(p db.ProjectId) []db.IncidentNotification {
+				return []db.IncidentNotification{notification(p, db.IntegrationTypePagerduty, now.Add(-31*timeseries.Minute), model.CRITICAL, "", now.Add(-31*timeseries.Minute))}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, project := newTestNotifier(t, tt.integrations)
+			ns := tt.notifications(project.Id)
+			for _, i := range ns {
+				n.db.PutIncidentNotification(i)
+			}
+			n.renotify(project, policy, ns, now)
+			stored, err := n.db.GetIncidentNotifications(project.Id, "i1")
+			require.NoError(t, err)
+			reminders := 0
+			for _, i := range stored {
+				if i.Reason == db.IncidentNotificationReasonReminder {
+					reminders++
+				}
+			}
+			assert.Equal(t, tt.reminders, reminders)
+		})
+	}
+}
+
+func TestEscalate(t *testing.T) {
+	appId := model.NewApplicationId("default", model.ApplicationKindDeployment, "catalog")
+	now := timeseries.Time(10000)
+	incident := &db.Incident{ApplicationId: appId, Key: "i1", OpenedAt: now.Add(-20 * timeseries.Minute), Severity: model.CRITICAL}
+	pagerduty := &db.IntegrationPagerduty{IntegrationKey: "key", Incidents: true}
+
+	tests := []struct {
+		name         string
+		integrations db.Integrations
+		policy       db.EscalationPolicy
+		existing     bool
+		escalated    bool
+	}{
+		{
+			name:         "escalate",
+			integrations: db.Integrations{Pagerduty: pagerduty},
+			policy:       db.EscalationPolicy{EscalateAfter: 15 * timeseries.Minute, EscalateTo: db.IntegrationTypePagerduty},
+			escalated:    true,
+		},
+		{
+			name:         "too early",
+			integrations: db.Integrations{Pagerduty: pagerduty},
+			policy:       db.EscalationPolicy{EscalateAfter: 30 * timeseries.Minute, EscalateTo: db.IntegrationTypePagerduty},
+		},
+		{
+			name:         "already notified",
+			integrations: db.Integrations{Pagerduty: pagerduty},
+			policy:       db.EscalationPolicy{EscalateAfter: 15 * timeseries.Minute, EscalateTo: db.IntegrationTypePagerduty},
+			existing:     true,
+		},
+		{
+			name:         "incidents disabled",
+			integrations: db.Integrations{Pagerduty: &db.IntegrationPagerduty{IntegrationKey: "key"}},
+			policy:       db.EscalationPolicy{EscalateAfter: 15 * timeseries.Minute, EscalateTo: db.IntegrationTypePagerduty},
+		},
+		{
+			name:   "not configured",
+			policy: db.EscalationPolicy{EscalateAfter: 15 * timeseries.Minute, EscalateTo: db.IntegrationTypePagerduty},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, project := newTestNotifier(t, tt.integrations)
+			var ns []db.IncidentNotification
+			if tt.existing {
+				ns = append(ns, db.IncidentNotification{
+					ProjectId:     project.Id,
+					ApplicationId: appId,
+					IncidentKey:   incident.Key,
+					Status:        model.CRITICAL,
+					Destination:   db.IntegrationTypePagerduty,
+					Timestamp:     incident.OpenedAt,
+					SentAt:        incident.OpenedAt,
+				})
+				n.db.PutIncidentNotification(ns[0])
+			}
+			n.escalate(project, &tt.policy, incident, ns, now)
+			stored, err := n.db.GetIncidentNotifications(project.Id, incident.Key)
+			require.NoError(t, err)
+			escalated := false
+			for _, i := range stored {
+				if i.Reason == db.IncidentNotificationReasonEscalation {
+					escalated = true
+				}
+			}
+			assert.Equal(t, tt.escalated, escalated)
+		})
+	}
+}
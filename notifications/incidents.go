@@ -6,19 +6,50 @@ import (
 	"github.com/coroot/coroot/db"
 	"github.com/coroot/coroot/model"
 	"github.com/coroot/coroot/timeseries"
+	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/klog"
 	"time"
 )
 
 type IncidentNotifier struct {
 	db *db.DB
+
+	sent        *prometheus.CounterVec
+	failures    *prometheus.CounterVec
+	deadLetters *prometheus.GaugeVec
 }
 
 func NewIncidentNotifier(db *db.DB) *IncidentNotifier {
-	n := IncidentNotifier{db: db}
+	n := IncidentNotifier{
+		db: db,
+		sent: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "coroot_incident_notifications_sent_total",
+			},
+			[]string{"destination"},
+		),
+		failures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "coroot_incident_notification_failures_total",
+			},
+			[]string{"destination"},
+		),
+		deadLetters: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "coroot_incident_notification_dead_letters",
+			},
+			[]string{"destination"},
+		),
+	}
+	prometheus.MustRegister(n.sent)
+	prometheus.MustRegister(n.failures)
+	prometheus.MustRegister(n.deadLetters)
+
 	go func() {
 		for range time.Tick(retryInterval) {
+			n.escalateIncidents()
 			n.sendIncidents()
+			n.updateDeadLetterStats()
 		}
 	}()
 	return &n
@@ -27,9 +58,10 @@ func NewIncidentNotifier(db *db.DB) *IncidentNotifier {
 func (n *IncidentNotifier) Enqueue(project *db.Project, app *model.Application, incident *db.Incident, now timeseries.Time) {
 	integrations := project.Settings.Integrations
 	for _, i := range integrations.GetInfo() {
-		if i.Configured && i.Incidents {
-			n.enqueue(project, app, incident, i.Type, now)
+		if !i.Configured || !i.Incidents {
+			continue
 		}
+		n.enqueue(project, app, incident, i.Type, now, db.IncidentNotificationReasonStatusChange)
 	}
 	n.sendIncidents()
 }
@@ -53,6 +85,7 @@ func (n *IncidentNotifier) sendIncidents() {
 		projects[p.Id] = p
 	}
 	failedDestinations := map[destinationKey]bool{}
+	digests := map[destinationKey][]db.IncidentNotification{}
 	notifications, err := n.db.GetNotSentIncidentNotifications(timeseries.Now().Add(-retryWindow))
 	if err != nil {
 		klog.Errorln(err)
@@ -68,10 +101,15 @@ func (n *IncidentNotifier) sendIncidents() {
 			continue
 		}
 		integrations := project.Settings.Integrations
+		if digestEnabled(project, notification.Destination) {
+			digests[dKey] = append(digests[dKey], notification)
+			continue
+		}
 		var sendErr error
-		client := getClient(notification.Destination, integrations)
+		client := getClient(project, &notification)
 		if client != nil {
-			if notification.Destination == db.IntegrationTypeSlack {
+			switch notification.Destination {
+			case db.IntegrationTypeSlack, db.IntegrationTypeMattermost, db.IntegrationTypeTelegram:
 				if prevNotifications, err := n.db.GetPreviousIncidentNotifications(notification); err != nil {
 					klog.Errorln(err)
 				} else {
@@ -81,24 +119,104 @@ func (n *IncidentNotifier) sendIncidents() {
 						}
 					}
 				}
+				if notification.Destination == db.IntegrationTypeSlack && notification.ExternalKey == "" && notification.Status > model.OK {
+					notification.ExternalKey = n.getDeploymentThread(notification)
+				}
 			}
 			ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
 			sendErr = client.SendIncident(ctx, integrations.BaseUrl, &notification)
 			cancel()
 		}
 		if sendErr != nil {
-			klog.Errorf("send error %s: %s", notification.Destination, sendErr)
 			failedDestinations[dKey] = true
+			n.onSendError(notification, sendErr)
 		} else {
-			notification.SentAt = timeseries.Now()
-			if err := n.db.UpdateIncidentNotification(notification); err != nil {
-				klog.Errorln(err)
+			n.onSent(notification, timeseries.Now())
+		}
+	}
+	n.sendDigests(projects, digests)
+}
+
+func (n *IncidentNotifier) onSent(notification db.IncidentNotification, now timeseries.Time) {
+	n.sent.WithLabelValues(string(notification.Destination)).Inc()
+	notification.SentAt = now
+	if err := n.db.UpdateIncidentNotification(notification); err != nil {
+		klog.Errorln(err)
+	}
+}
+
+func (n *IncidentNotifier) onSendError(notification db.IncidentNotification, sendErr error) {
+	klog.Errorf("send error %s: %s", notification.Destination, sendErr)
+	n.failures.WithLabelValues(string(notification.Destination)).Inc()
+	if err := n.db.UpdateIncidentNotificationError(notification, sendErr); err != nil {
+		klog.Errorln(err)
+	}
+}
+
+func (n *IncidentNotifier) updateDeadLetterStats() {
+	stat, err := n.db.GetDeadLetterIncidentNotificationsStat(timeseries.Now().Add(-retryWindow))
+	if err != nil {
+		klog.Errorln(err)
+		return
+	}
+	n.deadLetters.Reset()
+	for destination, count := range stat {
+		n.deadLetters.WithLabelValues(string(destination)).Set(float64(count))
+	}
+}
+
+func (n *IncidentNotifier) sendDigests(projects map[db.ProjectId]*db.Project, digests map[destinationKey][]db.IncidentNotification) {
+	now := timeseries.Now()
+	for dKey, notifications := range digests {
+		project := projects[dKey.projectId]
+		lastSentAt, err := n.db.GetLastIncidentNotificationSentAt(dKey.projectId, dKey.integration)
+		if err != nil {
+			klog.Errorln(err)
+			continue
+		}
+		interval := project.Settings.NotificationPolicy.DigestInterval
+		if interval > db.MaxDigestInterval { // pending rows must not fall out of the retry window
+			interval = db.MaxDigestInterval
+		}
+		if now.Sub(lastSentAt) < interval {
+			continue
+		}
+		integrations := project.Settings.Integrations
+		client, ok := getClient(project, &notifications[0]).(DigestClient)
+		if !ok {
+			for _, notification := range notifications {
+				if err := n.db.DiscardIncidentNotification(notification.ProjectId, notification.IncidentKey, notification.Destination, notification.Timestamp, now); err != nil {
+					klog.Errorln(err)
+				}
+			}
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		err = client.SendIncidentDigest(ctx, integrations.BaseUrl, notifications)
+		cancel()
+		for _, notification := range notifications {
+			if err != nil {
+				n.onSendError(notification, err)
+			} else {
+				n.onSent(notification, now)
 			}
 		}
 	}
 }
 
-func (n *IncidentNotifier) enqueue(project *db.Project, app *model.Application, incident *db.Incident, destination db.IntegrationType, now timeseries.Time) {
+func digestEnabled(project *db.Project, destination db.IntegrationType) bool {
+	policy := project.Settings.NotificationPolicy
+	if policy == nil || policy.DigestInterval <= 0 {
+		return false
+	}
+	switch destination {
+	case db.IntegrationTypeSlack, db.IntegrationTypeTeams, db.IntegrationTypeMattermost, db.IntegrationTypeTelegram:
+		return true
+	}
+	return false
+}
+
+func (n *IncidentNotifier) enqueue(project *db.Project, app *model.Application, incident *db.Incident, destination db.IntegrationType, now timeseries.Time, reason db.IncidentNotificationReason) {
 	notification := db.IncidentNotification{
 		ProjectId:     project.Id,
 		ApplicationId: app.Id,
@@ -106,9 +224,10 @@ func (n *IncidentNotifier) enqueue(project *db.Project, app *model.Application,
 		Destination:   destination,
 		Timestamp:     now,
 		Status:        incident.Severity,
+		Reason:        reason,
 	}
 	switch destination {
-	case db.IntegrationTypeSlack, db.IntegrationTypeTeams:
+	case db.IntegrationTypeSlack, db.IntegrationTypeTeams, db.IntegrationTypeMattermost, db.IntegrationTypeTelegram:
 		if incident.Resolved() {
 			n.onResolve("", notification, incidentDetails(app, incident))
 		} else {
@@ -155,6 +274,34 @@ func (n *IncidentNotifier) onResolve(externalKey string, notification db.Inciden
 	n.db.PutIncidentNotification(notification)
 }
 
+func (n *IncidentNotifier) getDeploymentThread(notification db.IncidentNotification) string {
+	incident, err := n.db.GetIncidentByKey(notification.ProjectId, notification.IncidentKey)
+	if err != nil {
+		klog.Errorln(err)
+		return ""
+	}
+	deployments, err := n.db.GetApplicationDeployments(notification.ProjectId)
+	if err != nil {
+		klog.Errorln(err)
+		return ""
+	}
+	ds := deployments[notification.ApplicationId]
+	for i := len(ds) - 1; i >= 0; i-- {
+		d := ds[i]
+		if d.StartedAt.After(incident.OpenedAt) {
+			continue
+		}
+		if incident.OpenedAt.Sub(d.StartedAt) > deploymentIncidentWindow {
+			break
+		}
+		if d.Notifications != nil && d.Notifications.Slack.Channel != "" && d.Notifications.Slack.ThreadTs != "" {
+			return fmt.Sprintf("%s:%s", d.Notifications.Slack.Channel, d.Notifications.Slack.ThreadTs)
+		}
+		break
+	}
+	return ""
+}
+
 func (n *IncidentNotifier) getOpenIncidents(notification db.IncidentNotification) (string, string, error) {
 	prevNotifications, err := n.db.GetPreviousIncidentNotifications(notification)
 	if err != nil {
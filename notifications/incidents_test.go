@@ -0,0 +1,82 @@
+package notifications
+
+import (
+	"github.com/coroot/coroot/db"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSendDigests(t *testing.T) {
+	appId := model.NewApplicationId("default", model.ApplicationKindDeployment, "catalog")
+	now := timeseries.Now()
+	unreachableTeams := &db.IntegrationTeams{WebhookUrl: "http://127.0.0.1:1/webhook", Incidents: true}
+
+	tests := []struct {
+		name         string
+		integrations db.Integrations
+		interval     timeseries.Duration
+		lastSentAt   timeseries.Time
+		sent         bool
+		discarded    bool
+		attempts     int
+	}{
+		{
+			name:         "no client",
+			integrations: db.Integrations{Teams: &db.IntegrationTeams{WebhookUrl: "http://127.0.0.1:1/webhook"}},
+			interval:     10 * timeseries.Minute,
+			discarded:    true,
+		},
+		{
+			name:         "interval not elapsed",
+			integrations: db.Integrations{Teams: unreachableTeams},
+			interval:     10 * timeseries.Minute,
+			lastSentAt:   now.Add(-5 * timeseries.Minute),
+		},
+		{
+			name:         "send error",
+			integrations: db.Integrations{Teams: unreachableTeams},
+			interval:     10 * timeseries.Minute,
+			lastSentAt:   now.Add(-15 * timeseries.Minute),
+			attempts:     1,
+		},
+		{
+			name:         "interval is capped",
+			integrations: db.Integrations{Teams: unreachableTeams},
+			interval:     timeseries.Hour,
+			lastSentAt:   now.Add(-db.MaxDigestInterval),
+			attempts:     1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, project := newTestNotifier(t, tt.integrations)
+			project.Settings.NotificationPolicy = &db.NotificationPolicy{DigestInterval: tt.interval}
+			if !tt.lastSentAt.IsZero() {
+				sent := db.IncidentNotification{
+					ProjectId: project.Id, ApplicationId: appId, IncidentKey: "i0", Status: model.WARNING,
+					Destination: db.IntegrationTypeTeams, Timestamp: tt.lastSentAt, SentAt: tt.lastSentAt,
+				}
+				n.db.PutIncidentNotification(sent)
+				require.NoError(t, n.db.UpdateIncidentNotification(sent))
+			}
+			notification := db.IncidentNotification{
+				ProjectId: project.Id, ApplicationId: appId, IncidentKey: "i1", Status: model.CRITICAL,
+				Destination: db.IntegrationTypeTeams, Timestamp: now.Add(-timeseries.Minute),
+			}
+			n.db.PutIncidentNotification(notification)
+
+			dKey := destinationKey{integration: db.IntegrationTypeTeams, projectId: project.Id}
+			n.sendDigests(map[db.ProjectId]*db.Project{project.Id: project}, map[destinationKey][]db.IncidentNotification{dKey: {notification}})
+
+			stored, err := n.db.GetIncidentNotifications(project.Id, "i1")
+			require.NoError(t, err)
+			require.Len(t, stored, 1)
+			assert.Equal(t, tt.sent, !stored[0].SentAt.IsZero())
+			assert.Equal(t, tt.discarded, !stored[0].DiscardedAt.IsZero())
+			assert.Equal(t, tt.attempts, stored[0].Attempts)
+		})
+	}
+}
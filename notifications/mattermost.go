@@ -0,0 +1,173 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/coroot/coroot/db"
+	"github.com/coroot/coroot/model"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type Mattermost struct {
+	templates
+	url       string
+	token     string
+	channelId string
+	client    *http.Client
+}
+
+func NewMattermost(url, token, channelId string) *Mattermost {
+	return &Mattermost{
+		url:       strings.TrimRight(url, "/"),
+		token:     token,
+		channelId: channelId,
+		client:    &http.Client{},
+	}
+}
+
+func (m *Mattermost) SendIncident(ctx context.Context, baseUrl string, n *db.IncidentNotification) error {
+	var lines []string
+	if text, ok := m.incident(baseUrl, n); ok {
+		lines = append(lines, text)
+	} else {
+		if n.Status == model.OK {
+			lines = append(lines, fmt.Sprintf("[**%s** incident resolved](%s)", n.ApplicationId.Name, incidentUrl(baseUrl, n)))
+		} else {
+			lines = append(lines, fmt.Sprintf("[%s] [**%s** %s](%s)", strings.ToUpper(n.Status.String()), n.ApplicationId.Name, incidentHeader(n), incidentUrl(baseUrl, n)))
+		}
+		if n.Details != nil {
+			for _, r := range n.Details.Reports {
+				lines = append(lines, fmt.Sprintf("* **%s** / %s: %s", r.Name, r.Check, r.Message))
+			}
+			if len(n.Details.Hints) > 0 {
+				lines = append(lines, "**Possible causes:**")
+				for _, h := range n.Details.Hints {
+					lines = append(lines, fmt.Sprintf("* **%s**: %s", h.Title, h.Explanation))
+				}
+			}
+		}
+	}
+	id, err := m.post(ctx, n.ExternalKey, strings.Join(lines, "\n"))
+	if err != nil {
+		return err
+	}
+	if n.ExternalKey == "" {
+		n.ExternalKey = id
+	}
+	return nil
+}
+
+func (m *Mattermost) SendIncidentDigest(ctx context.Context, baseUrl string, ns []db.IncidentNotification) error {
+	items, _ := digestItems(ns)
+	lines := []string{fmt.Sprintf("**Incident digest: %d updates**", len(ns))}
+	for _, i := range items {
+		lines = append(lines, fmt.Sprintf("* [%s] [**%s**](%s) %s", strings.ToUpper(i.last.Status.String()), i.last.ApplicationId.Name, incidentUrl(baseUrl, &i.last), i.text()))
+	}
+	_, err := m.post(ctx, "", strings.Join(lines, "\n"))
+	return err
+}
+
+func (m *Mattermost) SendDeployment(ctx context.Context, project *db.Project, ds model.ApplicationDeploymentStatus) error {
+	d := ds.Deployment
+
+	status := "Deployed"
+	switch ds.State {
+	case model.ApplicationDeploymentStateInProgress:
+		status = "In-progress"
+	case model.ApplicationDeploymentStateStuck:
+		status = "Stuck"
+	case model.ApplicationDeploymentStateCancelled:
+		status = "Cancelled"
+	}
+
+	url := deploymentUrl(project.Settings.Integrations.BaseUrl, project.Id, d)
+	message := fmt.Sprintf(
+		"Deployment of [**%s**](%s) to **%s**\n**Status:** %s\n**Version:** [%s](%s)",
+		d.ApplicationId.Name, url, project.Name, status, d.Version(), url,
+	)
+	if d.Notifications.Mattermost.PostId == "" {
+		id, err := m.post(ctx, "", message)
+		if err != nil {
+			return err
+		}
+		d.Notifications.Mattermost.PostId = id
+	} else if err := m.patch(ctx, d.Notifications.Mattermost.PostId, message); err != nil {
+		return err
+	}
+
+	reply := ds.Message
+	if ds.State == model.ApplicationDeploymentStateSummary {
+		summary := "No notable changes"
+		if len(ds.Summary) > 0 {
+			var items []string
+			for _, s := range ds.Summary {
+				items = append(items, fmt.Sprintf("%s %s", s.Emoji(), s.Message))
+			}
+			summary = strings.Join(items, "\n")
+		}
+		if text, ok := m.deploymentSummary(project, ds); ok {
+			summary = text
+		}
+		reply = "**Summary**\n" + summary
+	}
+	_, err := m.post(ctx, d.Notifications.Mattermost.PostId, reply)
+	return err
+}
+
+func (m *Mattermost) post(ctx context.Context, rootId, message string) (string, error) {
+	body := map[string]string{"channel_id": m.channelId, "message": message}
+	if rootId != "" {
+		body["root_id"] = rootId
+	}
+	var res struct {
+		Id string `json:"id"`
+	}
+	if err := m.do(ctx, http.MethodPost, "/api/v4/posts", body, &res); err != nil {
+		return "", err
+	}
+	return res.Id, nil
+}
+
+func (m *Mattermost) patch(ctx context.Context, postId, message string) error {
+	return m.do(ctx, http.MethodPut, "/api/v4/posts/"+postId+"/patch", map[string]string{"message": message}, nil)
+}
+
+func (m *Mattermost) do(ctx context.Context, method, path string, body, res any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, m.url+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.token)
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("mattermost error: %w", err)
+	}
+	defer resp.Body.Close()
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("mattermost error: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		var e struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(payload, &e)
+		if e.Message == "" {
+			e.Message = resp.Status
+		}
+		return fmt.Errorf("mattermost error: %s", e.Message)
+	}
+	if res == nil {
+		return nil
+	}
+	return json.Unmarshal(payload, res)
+}
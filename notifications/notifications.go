@@ -13,40 +13,122 @@ const (
 	sendTimeout   = 30 * time.Second
 	retryInterval = time.Minute
 	retryWindow   = timeseries.Hour
+
+	deploymentIncidentWindow = 30 * timeseries.Minute
 )
 
+// DeadLetterBefore returns the time before which unsent notifications are no longer retried automatically.
+func DeadLetterBefore(now timeseries.Time) timeseries.Time {
+	return now.Add(-retryWindow)
+}
+
 type NotificationClient interface {
 	SendIncident(ctx context.Context, baseUrl string, n *db.IncidentNotification) error
 }
 
-func getClient(destination db.IntegrationType, integrations db.Integrations) NotificationClient {
-	switch destination {
+type DigestClient interface {
+	SendIncidentDigest(ctx context.Context, baseUrl string, ns []db.IncidentNotification) error
+}
+
+type digestItem struct {
+	last    db.IncidentNotification
+	updates int
+}
+
+func (i digestItem) text() string {
+	res := "incident resolved"
+	if i.last.Status != model.OK {
+		res = incidentHeader(&i.last)
+	}
+	if i.updates > 1 {
+		res += fmt.Sprintf(" (%d updates)", i.updates)
+	}
+	return res
+}
+
+func digestItems(ns []db.IncidentNotification) ([]digestItem, model.Status) {
+	var res []digestItem
+	byIncident := map[string]int{}
+	status := model.OK
+	for _, n := range ns {
+		idx, ok := byIncident[n.IncidentKey]
+		if !ok {
+			idx = len(res)
+			byIncident[n.IncidentKey] = idx
+			res = append(res, digestItem{})
+		}
+		res[idx].last = n
+		res[idx].updates++
+	}
+	for _, i := range res {
+		if i.last.Status > status {
+			status = i.last.Status
+		}
+	}
+	return res, status
+}
+
+func getClient(project *db.Project, n *db.IncidentNotification) NotificationClient {
+	integrations := project.Settings.Integrations
+	switch n.Destination {
 	case db.IntegrationTypeSlack:
 		if cfg := integrations.Slack; cfg != nil && cfg.Incidents {
-			return NewSlack(cfg.Token, cfg.DefaultChannel)
+			c := NewSlack(cfg.Token, cfg.DefaultChannel)
+			c.SetTemplates(project.Name, cfg.Templates)
+			return c
 		}
 	case db.IntegrationTypeTeams:
 		if cfg := integrations.Teams; cfg != nil && cfg.Incidents {
-			return NewTeams(cfg.WebhookUrl)
+			c := NewTeams(cfg.WebhookUrl)
+			c.SetTemplates(project.Name, cfg.Templates)
+			return c
+		}
+	case db.IntegrationTypeMattermost:
+		if cfg := integrations.Mattermost; cfg != nil && cfg.Incidents {
+			c := NewMattermost(cfg.Url, cfg.Token, cfg.ChannelId)
+			c.SetTemplates(project.Name, cfg.Templates)
+			return c
+		}
+	case db.IntegrationTypeTelegram:
+		if cfg := integrations.Telegram; cfg != nil && cfg.Incidents {
+			c := NewTelegram(cfg.BotToken, cfg.ChatId)
+			c.SetTemplates(project.Name, cfg.Templates)
+			return c
 		}
 	case db.IntegrationTypePagerduty:
 		if cfg := integrations.Pagerduty; cfg != nil && cfg.Incidents {
-			return NewPagerduty(cfg.IntegrationKey)
+			c := NewPagerduty(cfg.IntegrationKey)
+			c.SetTemplates(project.Name, cfg.Templates)
+			return c
 		}
 	case db.IntegrationTypeOpsgenie:
 		if cfg := integrations.Opsgenie; cfg != nil && cfg.Incidents {
-			return NewOpsgenie(cfg.ApiKey, cfg.EUInstance)
+			c := NewOpsgenie(cfg.ApiKey, cfg.EUInstance)
+			c.SetTemplates(project.Name, cfg.Templates)
+			return c
 		}
 	}
 	return nil
 }
 
+func incidentsEnabled(project *db.Project, destination db.IntegrationType) bool {
+	for _, i := range project.Settings.Integrations.GetInfo() {
+		if i.Type == destination {
+			return i.Configured && i.Incidents
+		}
+	}
+	return false
+}
+
 func incidentDetails(app *model.Application, incident *db.Incident) *db.IncidentNotificationDetails {
+	if incident.CheckId != "" {
+		return checkIncidentDetails(app, incident)
+	}
 	var reports []db.IncidentNotificationDetailsReport
 	if !incident.Resolved() {
 		for _, r := range app.Reports {
 			for _, ch := range r.Checks {
-				if ch.Status < model.WARNING {
+				if ch.Status < model.WARNING || ch.Id == model.Checks.SLODependency.Id {
 					continue
 				}
 				reports = append(reports, db.IncidentNotificationDetailsReport{Name: r.Name, Check: ch.Title, Message: ch.Message})
@@ -58,14 +140,58 @@ func incidentDetails(app *model.Application, incident *db.Incident) *db.Incident
 				continue
 			}
 			for _, ch := range r.Checks {
+				if ch.Id == model.Checks.SLODependency.Id {
+					continue
+				}
 				reports = append(reports, db.IncidentNotificationDetailsReport{Name: r.Name, Check: ch.Title, Message: ch.Message})
 			}
 		}
 	}
-	if len(reports) == 0 {
+	var hints []model.RootCauseHint
+	if !incident.Resolved() {
+		for _, h := range app.RootCauseHints {
+			hints = append(hints, *h)
+		}
+	}
+	if len(reports) == 0 && len(hints) == 0 {
+		return nil
+	}
+	return &db.IncidentNotificationDetails{Reports: reports, Hints: hints}
+}
+
+func checkIncidentDetails(app *model.Application, incident *db.Incident) *db.IncidentNotificationDetails {
+	var report model.AuditReportName
+	var check *model.Check
+	for _, r := range app.Reports {
+		for _, ch := range r.Checks {
+			if ch.Id != incident.CheckId {
+				continue
+			}
+			if check == nil || ch.Status > check.Status {
+				report, check = r.Name, ch
+			}
+		}
+	}
+	if check == nil {
 		return nil
 	}
-	return &db.IncidentNotificationDetails{Reports: reports}
+	return &db.IncidentNotificationDetails{
+		Reports: []db.IncidentNotificationDetailsReport{{Name: report, Check: check.Title, Message: check.Message}},
+		Check:   fmt.Sprintf("%s / %s", report, check.Title),
+	}
+}
+
+func incidentHeader(n *db.IncidentNotification) string {
+	if n.Details != nil && n.Details.Check != "" {
+		if n.Reason == db.IncidentNotificationReasonReminder {
+			return "still has a failing check: " + n.Details.Check
+		}
+		return "has a failing check: " + n.Details.Check
+	}
+	if n.Reason == db.IncidentNotificationReasonReminder {
+		return "is still not meeting its SLOs"
+	}
+	return "is not meeting its SLOs"
 }
 
 func incidentUrl(baseUrl string, n *db.IncidentNotification) string {
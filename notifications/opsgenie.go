@@ -13,6 +13,7 @@ import (
 )
 
 type Opsgenie struct {
+	templates
 	client *alert.Client
 }
 
@@ -42,7 +43,7 @@ func (og *Opsgenie) SendIncident(ctx context.Context, baseUrl string, n *db.Inci
 	}
 
 	req := &alert.CreateAlertRequest{
-		Message: fmt.Sprintf("[%s] %s is not meeting its SLOs", strings.ToUpper(n.Status.String()), n.ApplicationId.Name),
+		Message: fmt.Sprintf("[%s] %s %s", strings.ToUpper(n.Status.String()), n.ApplicationId.Name, incidentHeader(n)),
 		Alias:   n.ExternalKey,
 		Source:  "Coroot",
 	}
@@ -54,11 +55,22 @@ func (og *Opsgenie) SendIncident(ctx context.Context, baseUrl string, n *db.Inci
 	case model.INFO:
 		req.Priority = alert.P4
 	}
+	if text, ok := og.incident(baseUrl, n); ok {
+		req.Description = text
+		_, err := og.client.Create(ctx, req)
+		return err
+	}
 	if n.Details != nil && len(n.Details.Reports) > 0 {
 		for _, r := range n.Details.Reports {
 			req.Description += fmt.Sprintf("• %s / %s: %s\n", r.Name, r.Check, r.Message)
 		}
 	}
+	if n.Details != nil && len(n.Details.Hints) > 0 {
+		req.Description += "\nPossible causes:\n"
+		for _, h := range n.Details.Hints {
+			req.Description += fmt.Sprintf("• %s: %s\n", h.Title, h.Explanation)
+		}
+	}
 	req.Description += fmt.Sprintf("\n%s", incidentUrl(baseUrl, n))
 	_, err := og.client.Create(ctx, req)
 	return err
@@ -7,9 +7,13 @@ import (
 	"github.com/coroot/coroot/db"
 	"github.com/coroot/coroot/model"
 	"strings"
+	"unicode/utf8"
 )
 
+const pagerdutySummaryMaxLength = 1024
+
 type Pagerduty struct {
+	templates
 	integrationKey string
 }
 
@@ -29,19 +33,35 @@ func (pd *Pagerduty) SendIncident(ctx context.Context, baseUrl string, n *db.Inc
 		e.Client = "Coroot"
 		e.ClientURL = incidentUrl(baseUrl, n)
 		e.Payload = &pagerduty.V2Payload{
-			Summary:   fmt.Sprintf("[%s] %s is not meeting its SLOs", strings.ToUpper(n.Status.String()), n.ApplicationId.Name),
+			Summary:   fmt.Sprintf("[%s] %s %s", strings.ToUpper(n.Status.String()), n.ApplicationId.Name, incidentHeader(n)),
 			Source:    "Coroot",
 			Severity:  n.Status.String(),
 			Timestamp: n.Timestamp.ToStandard().String(),
 		}
-		if n.Details != nil && len(n.Details.Reports) > 0 {
+		if text, ok := pd.incident(baseUrl, n); ok {
+			e.Payload.Summary = truncate(text, pagerdutySummaryMaxLength)
+		}
+		if n.Details != nil && (len(n.Details.Reports) > 0 || len(n.Details.Hints) > 0) {
 			details := map[string]string{}
 			for _, r := range n.Details.Reports {
 				details[fmt.Sprintf("%s / %s", r.Name, r.Check)] = r.Message
 			}
+			for i, h := range n.Details.Hints {
+				details[fmt.Sprintf("Possible cause #%d: %s", i+1, h.Title)] = h.Explanation
+			}
 			e.Payload.Details = details
 		}
 	}
 	_, err := pagerduty.ManageEventWithContext(ctx, e)
 	return err
 }
+
+func truncate(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	for maxBytes > 0 && !utf8.RuneStart(s[maxBytes]) {
+		maxBytes--
+	}
+	return s[:maxBytes]
+}
@@ -11,6 +11,7 @@ import (
 )
 
 type Slack struct {
+	templates
 	channel string
 	client  *slack.Client
 }
@@ -36,29 +37,68 @@ func (s *Slack) SendIncident(ctx context.Context, baseUrl string, n *db.Incident
 		header = fmt.Sprintf("<%s|*%s* incident resolved>", incidentUrl(baseUrl, n), n.ApplicationId.Name)
 		snippet = fmt.Sprintf("%s incident resolved", n.ApplicationId.Name)
 	} else {
-		header = fmt.Sprintf("[%s] <%s|*%s* is not meeting its SLOs>", strings.ToUpper(n.Status.String()), incidentUrl(baseUrl, n), n.ApplicationId.Name)
-		snippet = fmt.Sprintf("%s is not meeting its SLOs", n.ApplicationId.Name)
+		header = fmt.Sprintf("[%s] <%s|*%s* %s>", strings.ToUpper(n.Status.String()), incidentUrl(baseUrl, n), n.ApplicationId.Name, incidentHeader(n))
+		snippet = fmt.Sprintf("%s %s", n.ApplicationId.Name, incidentHeader(n))
 	}
 	var details []string
-	if n.Details != nil {
+	if text, ok := s.incident(baseUrl, n); ok {
+		header, details = text, nil
+	} else if n.Details != nil {
 		for _, r := range n.Details.Reports {
 			details = append(details, fmt.Sprintf("• *%s* / %s: %s", r.Name, r.Check, r.Message))
 		}
+		if len(n.Details.Hints) > 0 {
+			details = append(details, "*Possible causes:*")
+			for _, h := range n.Details.Hints {
+				details = append(details, fmt.Sprintf("• *%s*: %s", h.Title, h.Explanation))
+			}
+		}
 	}
-	body := s.body(n.Status.Color(), snippet, s.section(s.text(header)), s.section(s.text(strings.Join(details, "\n"))))
+	body := s.body(n.Status.Color(), snippet, s.section(s.text("%s", header)), s.section(s.text("%s", strings.Join(details, "\n"))))
 	opts := []slack.MsgOption{body, slack.MsgOptionDisableLinkUnfurl()}
 	if ts != "" {
 		opts = append(opts, slack.MsgOptionTS(ts), slack.MsgOptionBroadcast())
 	}
-	var err error
-	ch, ts, err = s.client.PostMessageContext(ctx, ch, opts...)
+	ch, msgTs, err := s.client.PostMessageContext(ctx, ch, opts...)
 	if err != nil {
 		return fmt.Errorf("slack error: %w", err)
 	}
+	if ts == "" {
+		ts = msgTs
+	}
 	n.ExternalKey = fmt.Sprintf("%s:%s", ch, ts)
 	return nil
 }
 
+func (s *Slack) SendDeploymentToIncidentThread(ctx context.Context, project *db.Project, d *model.ApplicationDeployment, externalKey string) error {
+	parts := strings.Split(externalKey, ":")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid slack thread: %s", externalKey)
+	}
+	url := deploymentUrl(project.Settings.Integrations.BaseUrl, project.Id, d)
+	text := fmt.Sprintf("Deployment of <%s|*%s*> has started: <%s|*%s*>", url, d.ApplicationId.Name, url, d.Version())
+	body := s.body(model.INFO.Color(), fmt.Sprintf("Deployment of %s has started", d.ApplicationId.Name), s.section(s.text("%s", text)))
+	_, _, err := s.client.PostMessageContext(ctx, parts[0], body, slack.MsgOptionTS(parts[1]), slack.MsgOptionDisableLinkUnfurl())
+	if err != nil {
+		return fmt.Errorf("slack error: %w", err)
+	}
+	return nil
+}
+
+func (s *Slack) SendIncidentDigest(ctx context.Context, baseUrl string, ns []db.IncidentNotification) error {
+	items, status := digestItems(ns)
+	var lines []string
+	for _, i := range items {
+		lines = append(lines, fmt.Sprintf("• [%s] <%s|*%s*> %s", strings.ToUpper(i.last.Status.String()), incidentUrl(baseUrl, &i.last), i.last.ApplicationId.Name, i.text()))
+	}
+	snippet := fmt.Sprintf("Incident digest: %d updates", len(ns))
+	body := s.body(status.Color(), snippet, s.section(s.text("*%s*", snippet)), s.section(s.text("%s", strings.Join(lines, "\n"))))
+	if _, _, err := s.client.PostMessageContext(ctx, s.channel, body, slack.MsgOptionDisableLinkUnfurl()); err != nil {
+		return fmt.Errorf("slack error: %w", err)
+	}
+	return nil
+}
+
 func (s *Slack) SendDeployment(ctx context.Context, project *db.Project, ds model.ApplicationDeploymentStatus) error {
 	d := ds.Deployment
 
@@ -81,6 +121,9 @@ func (s *Slack) SendDeployment(ctx context.Context, project *db.Project, ds mode
 				items += fmt.Sprintf("%s %s\n", s.Emoji(), s.Message)
 			}
 		}
+		if text, ok := s.deploymentSummary(project, ds); ok {
+			items = text
+		}
 		summary = s.section(s.text("*Summary*\n%s", items))
 	}
 	url := deploymentUrl(project.Settings.Integrations.BaseUrl, project.Id, d)
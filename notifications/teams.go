@@ -11,6 +11,7 @@ import (
 )
 
 type Teams struct {
+	templates
 	client     *goteamsnotify.TeamsClient
 	webhookUrl string
 }
@@ -27,18 +28,26 @@ func (t *Teams) SendIncident(ctx context.Context, baseUrl string, n *db.Incident
 	if n.Status == model.OK {
 		title = fmt.Sprintf("**%s** incident resolved", n.ApplicationId.Name)
 	} else {
-		title = fmt.Sprintf("[%s] **%s** is not meeting its SLOs", strings.ToUpper(n.Status.String()), n.ApplicationId.Name)
+		title = fmt.Sprintf("[%s] **%s** %s", strings.ToUpper(n.Status.String()), n.ApplicationId.Name, incidentHeader(n))
 	}
 
 	msg := messagecard.NewMessageCard()
 	msg.Summary = title
 	msg.ThemeColor = n.Status.Color()
 	msg.Text = "# " + title + "\n"
-	if n.Details != nil {
+	if text, ok := t.incident(baseUrl, n); ok {
+		_ = msg.AddSection(&messagecard.Section{Text: text})
+	} else if n.Details != nil {
 		s := &messagecard.Section{}
 		for _, r := range n.Details.Reports {
 			s.Text += fmt.Sprintf("• **%s** / %s: %s<br>", r.Name, r.Check, r.Message)
 		}
+		if len(n.Details.Hints) > 0 {
+			s.Text += "**Possible causes:**<br>"
+			for _, h := range n.Details.Hints {
+				s.Text += fmt.Sprintf("• **%s**: %s<br>", h.Title, h.Explanation)
+			}
+		}
 		_ = msg.AddSection(s)
 	}
 	action, _ := messagecard.NewPotentialAction(messagecard.PotentialActionOpenURIType, "View incident")
@@ -50,6 +59,21 @@ func (t *Teams) SendIncident(ctx context.Context, baseUrl string, n *db.Incident
 	return nil
 }
 
+func (t *Teams) SendIncidentDigest(ctx context.Context, baseUrl string, ns []db.IncidentNotification) error {
+	items, status := digestItems(ns)
+	title := fmt.Sprintf("Incident digest: %d updates", len(ns))
+	msg := messagecard.NewMessageCard()
+	msg.Summary = title
+	msg.ThemeColor = status.Color()
+	msg.Text = "# " + title + "\n"
+	s := &messagecard.Section{}
+	for _, i := range items {
+		s.Text += fmt.Sprintf("• [%s] [**%s**](%s) %s<br>", strings.ToUpper(i.last.Status.String()), i.last.ApplicationId.Name, incidentUrl(baseUrl, &i.last), i.text())
+	}
+	_ = msg.AddSection(s)
+	return t.client.SendWithContext(ctx, t.webhookUrl, msg)
+}
+
 func (t *Teams) SendDeployment(ctx context.Context, project *db.Project, ds model.ApplicationDeploymentStatus) error {
 	d := ds.Deployment
 
@@ -84,6 +108,9 @@ func (t *Teams) SendDeployment(ctx context.Context, project *db.Project, ds mode
 				summary += fmt.Sprintf("%s %s<br>", s.Emoji(), s.Message)
 			}
 		}
+		if text, ok := t.deploymentSummary(project, ds); ok {
+			summary = text
+		}
 		_ = msg.AddSection(&messagecard.Section{Text: "**Summary**<br>" + summary})
 	}
 
@@ -0,0 +1,171 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/coroot/coroot/db"
+	"github.com/coroot/coroot/model"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const telegramApiUrl = "https://api.telegram.org"
+
+type Telegram struct {
+	templates
+	botToken string
+	chatId   string
+	client   *http.Client
+}
+
+func NewTelegram(botToken, chatId string) *Telegram {
+	return &Telegram{
+		botToken: botToken,
+		chatId:   chatId,
+		client:   &http.Client{},
+	}
+}
+
+func (t *Telegram) SendIncident(ctx context.Context, baseUrl string, n *db.IncidentNotification) error {
+	var lines []string
+	if text, ok := t.incident(baseUrl, n); ok {
+		lines = append(lines, html.EscapeString(text))
+	} else {
+		if n.Status == model.OK {
+			lines = append(lines, fmt.Sprintf(`<a href="%s"><b>%s</b> incident resolved</a>`, incidentUrl(baseUrl, n), html.EscapeString(n.ApplicationId.Name)))
+		} else {
+			lines = append(lines, fmt.Sprintf(`[%s] <a href="%s"><b>%s</b> %s</a>`, strings.ToUpper(n.Status.String()), incidentUrl(baseUrl, n), html.EscapeString(n.ApplicationId.Name), html.EscapeString(incidentHeader(n))))
+		}
+		if n.Details != nil {
+			for _, r := range n.Details.Reports {
+				lines = append(lines, fmt.Sprintf("• <b>%s</b> / %s: %s", html.EscapeString(string(r.Name)), html.EscapeString(r.Check), html.EscapeString(r.Message)))
+			}
+			if len(n.Details.Hints) > 0 {
+				lines = append(lines, "<b>Possible causes:</b>")
+				for _, h := range n.Details.Hints {
+					lines = append(lines, fmt.Sprintf("• <b>%s</b>: %s", html.EscapeString(h.Title), html.EscapeString(h.Explanation)))
+				}
+			}
+		}
+	}
+	replyTo, _ := strconv.Atoi(n.ExternalKey)
+	id, err := t.send(ctx, replyTo, strings.Join(lines, "\n"))
+	if err != nil {
+		return err
+	}
+	if n.ExternalKey == "" {
+		n.ExternalKey = strconv.Itoa(id)
+	}
+	return nil
+}
+
+func (t *Telegram) SendIncidentDigest(ctx context.Context, baseUrl string, ns []db.IncidentNotification) error {
+	items, _ := digestItems(ns)
+	lines := []string{fmt.Sprintf("<b>Incident digest: %d updates</b>", len(ns))}
+	for _, i := range items {
+		lines = append(lines, fmt.Sprintf(`• [%s] <a href="%s"><b>%s</b></a> %s`, strings.ToUpper(i.last.Status.String()), incidentUrl(baseUrl, &i.last), html.EscapeString(i.last.ApplicationId.Name), html.EscapeString(i.text())))
+	}
+	_, err := t.send(ctx, 0, strings.Join(lines, "\n"))
+	return err
+}
+
+func (t *Telegram) SendDeployment(ctx context.Context, project *db.Project, ds model.ApplicationDeploymentStatus) error {
+	d := ds.Deployment
+
+	status := "Deployed"
+	switch ds.State {
+	case model.ApplicationDeploymentStateInProgress:
+		status = "In-progress"
+	case model.ApplicationDeploymentStateStuck:
+		status = "Stuck"
+	case model.ApplicationDeploymentStateCancelled:
+		status = "Cancelled"
+	}
+
+	if d.Notifications.Telegram.MessageId == 0 {
+		url := deploymentUrl(project.Settings.Integrations.BaseUrl, project.Id, d)
+		message := fmt.Sprintf(
+			`Deployment of <a href="%s"><b>%s</b></a> to <b>%s</b>`+"\n<b>Version:</b> %s",
+			url, html.EscapeString(d.ApplicationId.Name), html.EscapeString(project.Name), html.EscapeString(d.Version()),
+		)
+		id, err := t.send(ctx, 0, message)
+		if err != nil {
+			return err
+		}
+		d.Notifications.Telegram.MessageId = id
+	}
+
+	reply := fmt.Sprintf("<b>Status:</b> %s\n%s", status, html.EscapeString(ds.Message))
+	if ds.State == model.ApplicationDeploymentStateSummary {
+		summary := "No notable changes"
+		if len(ds.Summary) > 0 {
+			var items []string
+			for _, s := range ds.Summary {
+				items = append(items, fmt.Sprintf("%s %s", s.Emoji(), html.EscapeString(s.Message)))
+			}
+			summary = strings.Join(items, "\n")
+		}
+		if text, ok := t.deploymentSummary(project, ds); ok {
+			summary = html.EscapeString(text)
+		}
+		reply = "<b>Summary</b>\n" + summary
+	}
+	_, err := t.send(ctx, d.Notifications.Telegram.MessageId, reply)
+	return err
+}
+
+func (t *Telegram) send(ctx context.Context, replyTo int, text string) (int, error) {
+	body := map[string]any{
+		"chat_id":                  t.chatId,
+		"text":                     text,
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	}
+	if replyTo > 0 {
+		body["reply_to_message_id"] = replyTo
+		body["allow_sending_without_reply"] = true
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/bot%s/sendMessage", telegramApiUrl, t.botToken), bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := t.client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) { // the url contains the bot token
+			err = urlErr.Err
+		}
+		return 0, fmt.Errorf("telegram error: %w", err)
+	}
+	defer resp.Body.Close()
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("telegram error: %w", err)
+	}
+	var res struct {
+		Ok          bool   `json:"ok"`
+		Description string `json:"description"`
+		Result      struct {
+			MessageId int `json:"message_id"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(payload, &res); err != nil {
+		return 0, fmt.Errorf("telegram error: %s", resp.Status)
+	}
+	if !res.Ok {
+		return 0, fmt.Errorf("telegram error: %s", res.Description)
+	}
+	return res.Result.MessageId, nil
+}
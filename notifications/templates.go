@@ -0,0 +1,179 @@
+package notifications
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/coroot/coroot/db"
+	"github.com/coroot/coroot/model"
+	"github.com/coroot/coroot/timeseries"
+	"k8s.io/klog"
+	"strings"
+	"text/template"
+)
+
+type TemplateKind string
+
+const (
+	TemplateKindIncidentOpen      TemplateKind = "incident_open"
+	TemplateKindIncidentResolve   TemplateKind = "incident_resolve"
+	TemplateKindDeploymentSummary TemplateKind = "deployment_summary"
+)
+
+type IncidentTemplateData struct {
+	Project     string
+	Application model.ApplicationId
+	Status      string
+	Incident    string
+	Reason      db.IncidentNotificationReason
+	Check       string
+	Reports     []db.IncidentNotificationDetailsReport
+	Hints       []model.RootCauseHint
+	IncidentUrl string
+}
+
+type DeploymentTemplateData struct {
+	Project         string
+	Application     model.ApplicationId
+	Version         string
+	Status          string
+	Summary         []model.ApplicationDeploymentSummary
+	MetricsSnapshot *model.MetricsSnapshot
+	DeploymentUrl   string
+}
+
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+func ValidateTemplate(kind TemplateKind, text string) error {
+	project := &db.Project{Id: "sample", Name: "sample"}
+	n := &db.IncidentNotification{
+		ProjectId:     project.Id,
+		ApplicationId: model.NewApplicationId("default", model.ApplicationKindDeployment, "sample-app"),
+		IncidentKey:   "sample",
+		Status:        model.CRITICAL,
+		Details: &db.IncidentNotificationDetails{
+			Reports: []db.IncidentNotificationDetailsReport{
+				{Name: model.AuditReportSLO, Check: model.Checks.SLOAvailability.Title, Message: "error budget burn rate is 20x within 1 hour"},
+			},
+		},
+	}
+	_, err := PreviewTemplate(project, kind, text, n)
+	return err
+}
+
+func RenderTemplate(text string, data any) (string, error) {
+	t, err := template.New("").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func NewIncidentTemplateData(projectName, baseUrl string, n *db.IncidentNotification) IncidentTemplateData {
+	d := IncidentTemplateData{
+		Project:     projectName,
+		Application: n.ApplicationId,
+		Status:      n.Status.String(),
+		Incident:    n.IncidentKey,
+		Reason:      n.Reason,
+		IncidentUrl: incidentUrl(baseUrl, n),
+	}
+	if n.Details != nil {
+		d.Check = n.Details.Check
+		d.Reports = n.Details.Reports
+		d.Hints = n.Details.Hints
+	}
+	return d
+}
+
+func NewDeploymentTemplateData(project *db.Project, ds model.ApplicationDeploymentStatus) DeploymentTemplateData {
+	d := ds.Deployment
+	return DeploymentTemplateData{
+		Project:         project.Name,
+		Application:     d.ApplicationId,
+		Version:         d.Version(),
+		Status:          ds.Status.String(),
+		Summary:         ds.Summary,
+		MetricsSnapshot: d.MetricsSnapshot,
+		DeploymentUrl:   deploymentUrl(project.Settings.Integrations.BaseUrl, project.Id, d),
+	}
+}
+
+type templates struct {
+	project string
+	cfg     db.NotificationTemplates
+}
+
+func (t *templates) SetTemplates(project string, cfg db.NotificationTemplates) {
+	t.project = project
+	t.cfg = cfg
+}
+
+func (t *templates) incident(baseUrl string, n *db.IncidentNotification) (string, bool) {
+	text := t.cfg.IncidentOpen
+	if n.Status == model.OK {
+		text = t.cfg.IncidentResolve
+	}
+	if text == "" {
+		return "", false
+	}
+	return t.render(text, NewIncidentTemplateData(t.project, baseUrl, n))
+}
+
+func (t *templates) deploymentSummary(project *db.Project, ds model.ApplicationDeploymentStatus) (string, bool) {
+	if t.cfg.DeploymentSummary == "" {
+		return "", false
+	}
+	return t.render(t.cfg.DeploymentSummary, NewDeploymentTemplateData(project, ds))
+}
+
+func (t *templates) render(text string, data any) (string, bool) {
+	res, err := RenderTemplate(text, data)
+	if err != nil {
+		klog.Warningln("failed to render notification template, falling back to the default one:", err)
+		return "", false
+	}
+	return res, true
+}
+
+func PreviewTemplate(project *db.Project, kind TemplateKind, text string, n *db.IncidentNotification) (string, error) {
+	baseUrl := project.Settings.Integrations.BaseUrl
+	switch kind {
+	case TemplateKindIncidentOpen:
+		return RenderTemplate(text, NewIncidentTemplateData(project.Name, baseUrl, n))
+	case TemplateKindIncidentResolve:
+		resolved := *n
+		resolved.Status = model.OK
+		return RenderTemplate(text, NewIncidentTemplateData(project.Name, baseUrl, &resolved))
+	case TemplateKindDeploymentSummary:
+		now := timeseries.Now()
+		d := &model.ApplicationDeployment{
+			ApplicationId: n.ApplicationId,
+			Name:          "test-alert-fake-app-5d8f6c7b9",
+			StartedAt:     now.Add(-timeseries.Hour),
+			Details:       &model.ApplicationDeploymentDetails{ContainerImages: []string{"registry/test-alert-fake-app:v1.2.3"}},
+			MetricsSnapshot: &model.MetricsSnapshot{
+				Timestamp: now,
+				Duration:  model.ApplicationDeploymentMetricsSnapshotWindow,
+				Requests:  12000,
+				Errors:    12,
+			},
+		}
+		ds := model.ApplicationDeploymentStatus{
+			Status:     model.OK,
+			State:      model.ApplicationDeploymentStateSummary,
+			Deployment: d,
+			Summary: []model.ApplicationDeploymentSummary{
+				{Report: model.AuditReportSLO, Ok: true, Message: "Availability: 0.1% errors (-0.2%)"},
+			},
+		}
+		return RenderTemplate(text, NewDeploymentTemplateData(project, ds))
+	}
+	return "", fmt.Errorf("unknown template kind: %s", kind)
+}
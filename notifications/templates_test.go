@@ -0,0 +1,37 @@
+package notifications
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateTemplate(t *testing.T) {
+	tests := []struct {
+		kind  TemplateKind
+		text  string
+		valid bool
+	}{
+		{TemplateKindIncidentOpen, "", true},
+		{TemplateKindIncidentOpen, "{{.Application.Name}} is {{.Status | upper}}", true},
+		{TemplateKindIncidentOpen, "{{range .Reports}}{{.Check}}: {{.Message}}{{end}}", true},
+		{TemplateKindIncidentOpen, "{{.Application.Name", false},
+		{TemplateKindIncidentOpen, "{{.Unknown}}", false},
+		{TemplateKindIncidentOpen, "{{.Version}}", false},
+		{TemplateKindIncidentResolve, "{{.Incident}} resolved", true},
+		{TemplateKindDeploymentSummary, "{{.Version}}: {{.MetricsSnapshot.Requests}}", true},
+		{TemplateKindDeploymentSummary, "{{.IncidentUrl}}", false},
+		{TemplateKindDeploymentSummary, "{{index .Summary 5}}", false},
+	}
+	for _, tt := range tests {
+		err := ValidateTemplate(tt.kind, tt.text)
+		assert.Equal(t, tt.valid, err == nil, "%s: %s", tt.kind, tt.text)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "hello", truncate("hello", 10))
+	assert.Equal(t, "hel", truncate("hello", 3))
+	assert.Equal(t, "пр", truncate("привет", 5))
+	assert.Equal(t, "пр", truncate("привет", 4))
+	assert.Equal(t, "", truncate("😀", 3))
+}
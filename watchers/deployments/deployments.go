@@ -170,6 +170,8 @@ func (w *Watcher) sendNotifications(project *db.Project, world *model.World, now
 			needSave := false
 			if cfg := integrations.Slack; cfg != nil && cfg.Deployments && d.Notifications.Slack.State < ds.State {
 				client := notifications.NewSlack(cfg.Token, cfg.DefaultChannel)
+				client.SetTemplates(project.Name, cfg.Templates)
+				newThread := d.Notifications.Slack.ThreadTs == ""
 				ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
 				err := client.SendDeployment(ctx, project, ds)
 				cancel()
@@ -178,10 +180,14 @@ func (w *Watcher) sendNotifications(project *db.Project, world *model.World, now
 				} else {
 					d.Notifications.Slack.State = ds.State
 					needSave = true
+					if newThread {
+						w.notifyIncidentThread(project, client, d)
+					}
 				}
 			}
 			if cfg := integrations.Teams; cfg != nil && cfg.Deployments && d.Notifications.Teams.State < ds.State {
 				client := notifications.NewTeams(cfg.WebhookUrl)
+				client.SetTemplates(project.Name, cfg.Templates)
 				ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
 				err := client.SendDeployment(ctx, project, ds)
 				cancel()
@@ -192,6 +198,40 @@ func (w *Watcher) sendNotifications(project *db.Project, world *model.World, now
 					needSave = true
 				}
 			}
+			if cfg := integrations.Mattermost; cfg != nil && cfg.Deployments && d.Notifications.Mattermost.State < ds.State {
+				client := notifications.NewMattermost(cfg.Url, cfg.Token, cfg.ChannelId)
+				client.SetTemplates(project.Name, cfg.Templates)
+				postId := d.Notifications.Mattermost.PostId
+				ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+				err := client.SendDeployment(ctx, project, ds)
+				cancel()
+				if err != nil {
+					klog.Errorln(err)
+					if d.Notifications.Mattermost.PostId != postId { // the root post is created, only the reply has failed
+						needSave = true
+					}
+				} else {
+					d.Notifications.Mattermost.State = ds.State
+					needSave = true
+				}
+			}
+			if cfg := integrations.Telegram; cfg != nil && cfg.Deployments && d.Notifications.Telegram.State < ds.State {
+				client := notifications.NewTelegram(cfg.BotToken, cfg.ChatId)
+				client.SetTemplates(project.Name, cfg.Templates)
+				messageId := d.Notifications.Telegram.MessageId
+				ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+				err := client.SendDeployment(ctx, project, ds)
+				cancel()
+				if err != nil {
+					klog.Errorln(err)
+					if d.Notifications.Telegram.MessageId != messageId { // the root message is sent, only the reply has failed
+						needSave = true
+					}
+				} else {
+					d.Notifications.Telegram.State = ds.State
+					needSave = true
+				}
+			}
 			if !needSave {
 				continue
 			}
@@ -430,3 +470,36 @@ type rss struct {
 	time  timeseries.Time
 	names []string
 }
+
+func (w *Watcher) notifyIncidentThread(project *db.Project, client *notifications.Slack, d *model.ApplicationDeployment) {
+	incidents, err := w.db.GetOpenIncidents(project.Id)
+	if err != nil {
+		klog.Errorln(err)
+		return
+	}
+	for _, i := range incidents {
+		if i.ApplicationId != d.ApplicationId || i.CheckId != "" {
+			continue
+		}
+		ns, err := w.db.GetIncidentNotifications(project.Id, i.Key)
+		if err != nil {
+			klog.Errorln(err)
+			return
+		}
+		var externalKey string
+		for _, n := range ns {
+			if n.Destination == db.IntegrationTypeSlack && n.ExternalKey != "" {
+				externalKey = n.ExternalKey
+			}
+		}
+		if externalKey == "" {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		err = client.SendDeploymentToIncidentThread(ctx, project, d, externalKey)
+		cancel()
+		if err != nil {
+			klog.Errorln(err)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/coroot/coroot/notifications"
 	"github.com/coroot/coroot/timeseries"
 	"k8s.io/klog"
+	"sort"
 	"time"
 )
 
@@ -54,23 +55,165 @@ func (w *Watcher) checkProject(project *db.Project) {
 
 	auditor.Audit(world, project)
 
-	for _, app := range world.Applications {
-		status := app.SLOStatus()
-		if status == model.UNKNOWN {
-			continue
+	openIncidents := map[model.ApplicationId]string{}
+	openCheckIncidents := map[model.ApplicationId]map[model.CheckId]bool{}
+	incidents, err := w.db.GetOpenIncidents(project.Id)
+	if err != nil {
+		klog.Errorln(err)
+		return
+	}
+	for _, i := range incidents {
+		switch i.CheckId {
+		case "":
+			openIncidents[i.ApplicationId] = i.Key
+		case model.Checks.SLODependency.Id:
+		default:
+			if openCheckIncidents[i.ApplicationId] == nil {
+				openCheckIncidents[i.ApplicationId] = map[model.CheckId]bool{}
+			}
+			openCheckIncidents[i.ApplicationId][i.CheckId] = true
 		}
-		apps++
+	}
+
+	for _, app := range world.Applications {
 		now := timeseries.Now()
-		incident, err := w.db.CreateOrUpdateIncident(project.Id, app.Id, now, status)
-		if err != nil {
-			klog.Errorln(err)
-			continue
+		if status := app.SLOStatus(); status != model.UNKNOWN {
+			apps++
+			key := openIncidents[app.Id]
+			if incident := w.updateIncident(project, app, "", status, now); incident != nil {
+				key = incident.Key
+			}
+			if key != "" {
+				w.recordCheckTransitions(project, app, key, now)
+			}
+		}
+		if status := app.DependencySLOStatus(); status != model.UNKNOWN {
+			if status < model.WARNING {
+				status = model.OK
+			}
+			w.updateIncident(project, app, model.Checks.SLODependency.Id, status, now)
 		}
-		if incident == nil {
-			continue
+
+		for _, cs := range checkStatuses(project, world.CheckConfigs, app, openCheckIncidents[app.Id]) {
+			w.updateIncident(project, app, cs.id, cs.status, now)
+		}
+	}
+}
+
+type checkStatus struct {
+	id     model.CheckId
+	status model.Status
+}
+
+// checkStatuses also returns OK for open incidents of checks that no longer alert, so that they get resolved.
+func checkStatuses(project *db.Project, configs model.CheckConfigs, app *model.Application, open map[model.CheckId]bool) []checkStatus {
+	var res []checkStatus
+	seen := map[model.CheckId]bool{}
+	for _, r := range app.Reports {
+		for _, ch := range r.Checks {
+			if ch.Id == model.Checks.SLOAvailability.Id || ch.Id == model.Checks.SLOLatency.Id || ch.Id == model.Checks.SLODependency.Id {
+				continue
+			}
+			seen[ch.Id] = true
+			if !checkAlert(project, configs, app, ch.Id) {
+				if open[ch.Id] {
+					res = append(res, checkStatus{id: ch.Id, status: model.OK})
+				}
+				continue
+			}
+			status := model.OK
+			if ch.Status >= model.WARNING {
+				status = ch.Status
+			}
+			res = append(res, checkStatus{id: ch.Id, status: status})
+		}
+	}
+	var gone []model.CheckId
+	for id := range open {
+		if !seen[id] && !checkAlert(project, configs, app, id) {
+			gone = append(gone, id)
+		}
+	}
+	sort.Slice(gone, func(i, j int) bool {
+		return gone[i] < gone[j]
+	})
+	for _, id := range gone {
+		res = append(res, checkStatus{id: id, status: model.OK})
+	}
+	return res
+}
+
+func checkAlert(project *db.Project, configs model.CheckConfigs, app *model.Application, id model.CheckId) bool {
+	if model.IsCustomCheck(id) {
+		return customCheckAlert(project, id)
+	}
+	return configs.GetSimple(id, app.Id, app.Category).Alert
+}
+
+func customCheckAlert(project *db.Project, id model.CheckId) bool {
+	for i := range project.Settings.CustomChecks {
+		if project.Settings.CustomChecks[i].CheckId() == id {
+			return project.Settings.CustomChecks[i].Alert
+		}
+	}
+	return false
+}
+
+func (w *Watcher) updateIncident(project *db.Project, app *model.Application, checkId model.CheckId, status model.Status, now timeseries.Time) *db.Incident {
+	incident, err := w.db.CreateOrUpdateIncident(project.Id, app.Id, checkId, now, status, project.Settings.NotificationPolicy)
+	if err != nil {
+		klog.Errorln(err)
+		return nil
+	}
+	if incident == nil {
+		return nil
+	}
+	w.notifier.Enqueue(project, app, incident, now)
+	return incident
+}
+
+func (w *Watcher) recordCheckTransitions(project *db.Project, app *model.Application, incidentKey string, now timeseries.Time) {
+	recorded, err := w.db.GetIncidentCheckTransitions(project.Id, incidentKey)
+	if err != nil {
+		klog.Errorln(err)
+		return
+	}
+	transitions := checkTransitions(app, recorded, now)
+	if err := w.db.AddIncidentCheckTransitions(project.Id, incidentKey, transitions); err != nil {
+		klog.Errorln(err)
+	}
+}
+
+func checkTransitions(app *model.Application, recorded []db.IncidentCheckTransition, now timeseries.Time) []db.IncidentCheckTransition {
+	type checkKey struct {
+		id    model.CheckId
+		title string
+	}
+	last := map[checkKey]model.Status{}
+	for _, t := range recorded {
+		last[checkKey{id: t.CheckId, title: t.Title}] = t.Status
+	}
+	var res []db.IncidentCheckTransition
+	for _, r := range app.Reports {
+		for _, ch := range r.Checks {
+			if ch.Status == model.UNKNOWN {
+				continue
+			}
+			status := model.OK
+			if ch.Status >= model.WARNING {
+				status = ch.Status
+			}
+			prev, ok := last[checkKey{id: ch.Id, title: ch.Title}]
+			if !ok {
+				prev = model.OK
+			}
+			if status == prev {
+				continue
+			}
+			res = append(res, db.IncidentCheckTransition{Timestamp: now, Report: r.Name, CheckId: ch.Id, Title: ch.Title, Status: status, Message: ch.Message})
 		}
-		w.notifier.Enqueue(project, app, incident, now)
 	}
+	return res
 }
 
 func (w *Watcher) loadWorld(project *db.Project) (*model.World, error) {
@@ -84,6 +227,6 @@ func (w *Watcher) loadWorld(project *db.Project) (*model.World, error) {
 	}
 	step := project.Prometheus.RefreshInterval
 	to := cacheTo.Truncate(step)
-	from := to.Add(-timeseries.Hour)
+	from := to.Add(-model.CheckEvaluationWindow)
 	return constructor.New(w.db, project, cc).LoadWorld(context.Background(), from, to, step, nil)
 }
@@ -0,0 +1,124 @@
+package incidents
+
+import (
+	"github.com/coroot/coroot/db"
+	"github.com/coroot/coroot/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheckTransitions(t *testing.T) {
+	app := model.NewApplication(model.NewApplicationId("default", model.ApplicationKindDeployment, "catalog"))
+	app.Reports = []*model.AuditReport{
+		{
+			Name: model.AuditReportCPU,
+			Checks: []*model.Check{
+				{Id: model.Checks.CPUNode.Id, Title: "Node CPU", Status: model.WARNING, Message: "high CPU"},
+				{Id: model.Checks.CPUContainer.Id, Title: "Container CPU", Status: model.OK},
+			},
+		},
+		{
+			Name: model.AuditReportMemory,
+			Checks: []*model.Check{
+				{Id: model.Checks.MemoryOOM.Id, Title: "OOM", Status: model.UNKNOWN},
+			},
+		},
+	}
+
+	res := checkTransitions(app, nil, 100)
+	assert.Equal(t, []db.IncidentCheckTransition{
+		{Timestamp: 100, Report: model.AuditReportCPU, CheckId: model.Checks.CPUNode.Id, Title: "Node CPU", Status: model.WARNING, Message: "high CPU"},
+	}, res)
+
+	assert.Empty(t, checkTransitions(app, res, 200))
+
+	app.Reports[0].Checks[0].Status = model.CRITICAL
+	app.Reports[0].Checks[1].Status = model.WARNING
+	res = append(res, checkTransitions(app, res, 300)...)
+	assert.Len(t, res, 3)
+	assert.Equal(t, model.CRITICAL, res[1].Status)
+	assert.Equal(t, model.Checks.CPUContainer.Id, res[2].CheckId)
+
+	app.Reports[0].Checks[0].Status = model.OK
+	app.Reports[0].Checks[1].Status = model.INFO
+	res = checkTransitions(app, res, 400)
+	assert.Len(t, res, 2)
+	for _, tr := range res {
+		assert.Equal(t, model.OK, tr.Status)
+	}
+}
+
+func TestCheckTransitionsMultipleSLOs(t *testing.T) {
+	app := model.NewApplication(model.NewApplicationId("default", model.ApplicationKindDeployment, "catalog"))
+	app.Reports = []*model.AuditReport{
+		{
+			Name: model.AuditReportSLO,
+			Checks: []*model.Check{
+				{Id: model.Checks.SLOLatency.Id, Title: "Latency: p90", Status: model.CRITICAL},
+				{Id: model.Checks.SLOLatency.Id, Title: "Latency: p99", Status: model.OK},
+			},
+		},
+	}
+	res := checkTransitions(app, nil, 100)
+	assert.Len(t, res, 1)
+
+	app.Reports[0].Checks[1].Status = model.WARNING
+	res = checkTransitions(app, res, 200)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "Latency: p99", res[0].Title)
+}
+
+func TestCheckStatuses(t *testing.T) {
+	project := &db.Project{}
+	project.Settings.CustomChecks = []model.CustomCheck{{Id: "errors", Alert: true}, {Id: "muted"}, {Id: "idle", Alert: true}}
+	app := model.NewApplication(model.NewApplicationId("default", model.ApplicationKindDeployment, "consumer"))
+	app.Reports = []*model.AuditReport{
+		{
+			Name: model.AuditReportKafka,
+			Checks: []*model.Check{
+				{Id: model.Checks.KafkaConsumerLag.Id, Status: model.WARNING},
+			},
+		},
+		{
+			Name: model.AuditReportCPU,
+			Checks: []*model.Check{
+				{Id: model.Checks.CPUNode.Id, Status: model.WARNING},
+			},
+		},
+		{
+			Name: model.AuditReportCustom,
+			Checks: []*model.Check{
+				{Id: "Custom:errors", Status: model.CRITICAL},
+				{Id: "Custom:muted", Status: model.CRITICAL},
+			},
+		},
+	}
+
+	assert.Equal(t, []checkStatus{
+		{id: model.Checks.KafkaConsumerLag.Id, status: model.WARNING},
+		{id: "Custom:errors", status: model.CRITICAL},
+	}, checkStatuses(project, nil, app, nil))
+
+	open := map[model.CheckId]bool{
+		model.Checks.CPUNode.Id:   true,
+		"Custom:muted":            true,
+		"Custom:deleted":          true,
+		model.Checks.MemoryOOM.Id: true,
+	}
+	assert.Equal(t, []checkStatus{
+		{id: model.Checks.KafkaConsumerLag.Id, status: model.WARNING},
+		{id: model.Checks.CPUNode.Id, status: model.OK},
+		{id: "Custom:errors", status: model.CRITICAL},
+		{id: "Custom:muted", status: model.OK},
+		{id: "Custom:deleted", status: model.OK},
+		{id: model.Checks.MemoryOOM.Id, status: model.OK},
+	}, checkStatuses(project, nil, app, open))
+
+	configs := model.CheckConfigs{model.ApplicationIdZero: {model.Checks.KafkaConsumerLag.Id: []byte(`{"threshold": 1000, "alert": false}`)}}
+	app.Reports = app.Reports[1:]
+	open = map[model.CheckId]bool{model.Checks.KafkaConsumerLag.Id: true, "Custom:idle": true}
+	assert.Equal(t, []checkStatus{
+		{id: "Custom:errors", status: model.CRITICAL},
+		{id: model.Checks.KafkaConsumerLag.Id, status: model.OK},
+	}, checkStatuses(project, configs, app, open))
+}